@@ -0,0 +1,34 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"net/http"
+
+	"bitbucket.org/tshannon/freehold-sync/activity"
+)
+
+type activityInput struct {
+	activity.Filter
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
+}
+
+func activityGet(w http.ResponseWriter, r *http.Request) {
+	input := &activityInput{}
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	all, err := activity.Get(&input.Filter, input.Page, input.PageSize)
+	if errHandled(err, w) {
+		return
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+		Data:   all,
+	})
+}
@@ -0,0 +1,214 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+// Package activity records every change made while syncing, so what happened
+// to a file, and when, can be traced after the fact
+package activity
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/log"
+)
+
+const (
+	bucket   = datastore.BucketActivity
+	pageSize = 25
+	// maxTrim is the most old entries removed when a new entry is recorded
+	maxTrim = 100
+)
+
+// Directions of a change
+//	DirectionUp: The change was made to the remote file
+//	DirectionDown: The change was made to the local file
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+// Outcomes of a change
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailed  = "failed"
+)
+
+var (
+	maxEntries = 10000
+	maxAge     time.Duration
+)
+
+// SetRetention sets how many activity entries are kept, and how long they are kept for.  An
+// entries limit of 0 keeps any number of entries, and an age of 0 keeps entries of any age
+func SetRetention(entries int, age time.Duration) {
+	maxEntries = entries
+	maxAge = age
+}
+
+// Activity is a single change made while syncing
+type Activity struct {
+	When        time.Time     `json:"when"`
+	Profile     string        `json:"profile"`   // ID of the sync profile
+	Direction   string        `json:"direction"` // up or down
+	Change      string        `json:"change"`    // write, delete, rename or createDir
+	Path        string        `json:"path"`      // path relative to the sync profile's starting point
+	Size        int64         `json:"size"`
+	OldModified time.Time     `json:"oldModified"` // modified time of the changed file before the change
+	NewModified time.Time     `json:"newModified"` // modified time of the changed file after the change
+	Outcome     string        `json:"outcome"`     // success or failed
+	Error       string        `json:"error,omitempty"`
+	Duration    time.Duration `json:"duration"` // how long the change took in nanoseconds
+}
+
+// Record adds the activity to the datastore
+func Record(a *Activity) {
+	err := insert(a)
+	if err != nil {
+		log.Error(fmt.Sprintf("Error recording sync activity for %s: %s", a.Path, err), "Both",
+			log.Fields{Profile: a.Profile, Path: a.Path, Change: a.Change})
+	}
+}
+
+func insert(a *Activity) error {
+	if datastore.DB() == nil {
+		return nil
+	}
+
+	value, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	return datastore.DB().Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		err = b.Put(datastore.SequenceKey(seq), value)
+		if err != nil {
+			return err
+		}
+		err = trim(b, seq)
+		if err != nil {
+			return err
+		}
+		return updateStats(tx, a.Profile, a.When, func(c *Counters) {
+			c.count(a)
+		})
+	})
+}
+
+// trim removes the oldest entries which are past the retention limits
+func trim(b *bolt.Bucket, last uint64) error {
+	c := b.Cursor()
+	cutoff := time.Now().Add(-maxAge)
+
+	for i := 0; i < maxTrim; i++ {
+		k, v := c.First()
+		if k == nil {
+			return nil
+		}
+
+		count := last - binary.BigEndian.Uint64(k) + 1
+		if maxEntries <= 0 || count <= uint64(maxEntries) {
+			if maxAge <= 0 {
+				return nil
+			}
+			a := &Activity{}
+			if json.Unmarshal(v, a) != nil || a.When.After(cutoff) {
+				return nil
+			}
+		}
+
+		err := c.Delete()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter limits which activity is retrieved, empty values match all activity
+type Filter struct {
+	Profile   string    `json:"profile"`
+	Path      string    `json:"path"` // matches paths starting with this path
+	Change    string    `json:"change"`
+	Direction string    `json:"direction"`
+	Outcome   string    `json:"outcome"`
+	From      time.Time `json:"from"` // matches activity at or after this time
+	To        time.Time `json:"to"`   // matches activity before this time
+}
+
+func (f *Filter) match(a *Activity) bool {
+	if f.Profile != "" && a.Profile != f.Profile {
+		return false
+	}
+	if f.Path != "" && !strings.HasPrefix(a.Path, f.Path) {
+		return false
+	}
+	if f.Change != "" && a.Change != f.Change {
+		return false
+	}
+	if f.Direction != "" && a.Direction != f.Direction {
+		return false
+	}
+	if f.Outcome != "" && a.Outcome != f.Outcome {
+		return false
+	}
+	if !f.To.IsZero() && !a.When.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+// Get retrieves a page of the activity matching the filter, newest first.  A page size
+// of 0 uses the default page size
+func Get(filter *Filter, page, size int) ([]*Activity, error) {
+	if size <= 0 {
+		size = pageSize
+	}
+	skip := page * size
+	all := []*Activity{}
+
+	err := datastore.DB().View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucket)).Cursor()
+
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			a := &Activity{}
+			err := json.Unmarshal(v, a)
+			if err != nil {
+				return err
+			}
+			if !filter.From.IsZero() && a.When.Before(filter.From) {
+				// activity is in the order it happened, so the rest is older
+				break
+			}
+			if !filter.match(a) {
+				continue
+			}
+
+			if skip <= 0 {
+				all = append(all, a)
+				if len(all) >= size {
+					break
+				}
+			} else {
+				skip--
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
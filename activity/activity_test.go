@@ -0,0 +1,169 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package activity
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+)
+
+func openTestDatastore(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "freehold-sync-activity")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return func() {
+		datastore.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func clearActivity(t *testing.T) {
+	err := datastore.DB().Update(func(tx *bolt.Tx) error {
+		err := tx.DeleteBucket([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		_, err = tx.CreateBucket([]byte(bucket))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestActivityFilter(t *testing.T) {
+	defer openTestDatastore(t)()
+
+	now := time.Now()
+	all := []*Activity{
+		{When: now.Add(-3 * time.Hour), Profile: "one", Direction: DirectionUp, Change: "write",
+			Path: "/docs/a.txt", Outcome: OutcomeSuccess},
+		{When: now.Add(-2 * time.Hour), Profile: "one", Direction: DirectionDown, Change: "delete",
+			Path: "/docs/b.txt", Outcome: OutcomeSuccess},
+		{When: now.Add(-time.Hour), Profile: "two", Direction: DirectionUp, Change: "write",
+			Path: "/pics/c.jpg", Outcome: OutcomeFailed, Error: "failed"},
+		{When: now, Profile: "two", Direction: DirectionDown, Change: "rename",
+			Path: "/pics/d.jpg", Outcome: OutcomeSuccess},
+	}
+	for i := range all {
+		err := insert(all[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		paths  []string // newest first
+	}{
+		{"all", Filter{}, []string{"/pics/d.jpg", "/pics/c.jpg", "/docs/b.txt", "/docs/a.txt"}},
+		{"profile", Filter{Profile: "one"}, []string{"/docs/b.txt", "/docs/a.txt"}},
+		{"path prefix", Filter{Path: "/pics/"}, []string{"/pics/d.jpg", "/pics/c.jpg"}},
+		{"change", Filter{Change: "write"}, []string{"/pics/c.jpg", "/docs/a.txt"}},
+		{"direction", Filter{Direction: DirectionDown}, []string{"/pics/d.jpg", "/docs/b.txt"}},
+		{"outcome", Filter{Outcome: OutcomeFailed}, []string{"/pics/c.jpg"}},
+		{"from", Filter{From: now.Add(-90 * time.Minute)}, []string{"/pics/d.jpg", "/pics/c.jpg"}},
+		{"to", Filter{To: now.Add(-90 * time.Minute)}, []string{"/docs/b.txt", "/docs/a.txt"}},
+		{"time range", Filter{From: now.Add(-150 * time.Minute), To: now.Add(-30 * time.Minute)},
+			[]string{"/pics/c.jpg", "/docs/b.txt"}},
+		{"no match", Filter{Profile: "one", Path: "/pics/"}, nil},
+	}
+
+	for _, test := range tests {
+		activity, err := Get(&test.filter, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(activity) != len(test.paths) {
+			t.Errorf("%s: got %d entries, expected %d", test.name, len(activity), len(test.paths))
+			continue
+		}
+		for i := range activity {
+			if activity[i].Path != test.paths[i] {
+				t.Errorf("%s: entry %d is %s, expected %s", test.name, i, activity[i].Path, test.paths[i])
+			}
+		}
+	}
+
+	// pages
+	activity, err := Get(&Filter{}, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activity) != 1 || activity[0].Path != "/docs/a.txt" {
+		t.Errorf("Second page of 3 has %d entries, expected only /docs/a.txt", len(activity))
+	}
+}
+
+func TestActivityTrim(t *testing.T) {
+	defer openTestDatastore(t)()
+	defer SetRetention(maxEntries, maxAge)
+
+	old := time.Now().Add(-2 * time.Hour)
+	tests := []struct {
+		name     string
+		entries  int
+		age      time.Duration
+		old      int // entries from two hours ago, recorded before the retention is set
+		recorded int
+		kept     int
+	}{
+		{"under limit", 10, 0, 0, 5, 5},
+		{"over limit", 10, 0, 0, 15, 10},
+		{"no limit", 0, 0, 0, 15, 15},
+		{"old entries", 0, time.Hour, 5, 5, 5},
+		{"old entries over limit", 3, time.Hour, 5, 5, 3},
+		{"old entries kept", 0, 3 * time.Hour, 5, 5, 10},
+		{"more than trimmed at once", 0, time.Hour, maxTrim + 10, 1, 11},
+	}
+
+	for _, test := range tests {
+		clearActivity(t)
+		SetRetention(0, 0)
+
+		for i := 0; i < test.old+test.recorded; i++ {
+			a := &Activity{When: time.Now(), Profile: "profile", Path: fmt.Sprint(i)}
+			if i < test.old {
+				a.When = old
+			}
+			if i == test.old {
+				SetRetention(test.entries, test.age)
+			}
+			err := insert(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		activity, err := Get(&Filter{}, 0, test.old+test.recorded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(activity) != test.kept {
+			t.Errorf("%s: kept %d entries, expected %d", test.name, len(activity), test.kept)
+			continue
+		}
+		// the newest entries are kept
+		if newest := activity[0].Path; newest != fmt.Sprint(test.old+test.recorded-1) {
+			t.Errorf("%s: newest entry is %s, expected %d", test.name, newest, test.old+test.recorded-1)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/log"
+)
+
+const (
+	statsBucket = datastore.BucketStats
+	dayFormat   = "2006-01-02"
+	// maxStatsDays is how many days of counters are kept for each profile
+	maxStatsDays = 400
+)
+
+// Counters are the totals of what a profile synced over a period of time
+type Counters struct {
+	Uploaded   int64 `json:"uploaded"`
+	Downloaded int64 `json:"downloaded"`
+	Deleted    int64 `json:"deleted"`
+	Renamed    int64 `json:"renamed"`
+	BytesUp    int64 `json:"bytesUp"`
+	BytesDown  int64 `json:"bytesDown"`
+	Conflicts  int64 `json:"conflicts"`
+	Failures   int64 `json:"failures"`
+}
+
+func (c *Counters) add(other *Counters) {
+	c.Uploaded += other.Uploaded
+	c.Downloaded += other.Downloaded
+	c.Deleted += other.Deleted
+	c.Renamed += other.Renamed
+	c.BytesUp += other.BytesUp
+	c.BytesDown += other.BytesDown
+	c.Conflicts += other.Conflicts
+	c.Failures += other.Failures
+}
+
+// count adds the activity to the counters
+func (c *Counters) count(a *Activity) {
+	if a.Outcome != OutcomeSuccess {
+		c.Failures++
+		return
+	}
+
+	switch a.Change {
+	case "write":
+		if a.Direction == DirectionUp {
+			c.Uploaded++
+			c.BytesUp += a.Size
+		} else {
+			c.Downloaded++
+			c.BytesDown += a.Size
+		}
+	case "delete":
+		c.Deleted++
+	case "rename":
+		c.Renamed++
+	}
+}
+
+// Stats are the counters for a profile over a period starting on Day
+type Stats struct {
+	Day string `json:"day"`
+	Counters
+}
+
+// updateStats updates the profile's counters for the day of the passed in time
+func updateStats(tx *bolt.Tx, profileID string, when time.Time, update func(*Counters)) error {
+	b, err := tx.Bucket([]byte(statsBucket)).CreateBucketIfNotExists([]byte(profileID))
+	if err != nil {
+		return err
+	}
+
+	day := []byte(when.Format(dayFormat))
+	counters := &Counters{}
+	if v := b.Get(day); v != nil {
+		err = json.Unmarshal(v, counters)
+		if err != nil {
+			return err
+		}
+	}
+	update(counters)
+
+	value, err := json.Marshal(counters)
+	if err != nil {
+		return err
+	}
+	err = b.Put(day, value)
+	if err != nil {
+		return err
+	}
+
+	// days sort in order, so the oldest are first
+	cutoff := when.AddDate(0, 0, -maxStatsDays).Format(dayFormat)
+	c := b.Cursor()
+	for k, _ := c.First(); k != nil && string(k) < cutoff; k, _ = c.First() {
+		err = c.Delete()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Conflict counts a sync conflict for the profile
+func Conflict(profileID string) {
+	if datastore.DB() == nil {
+		return
+	}
+	err := datastore.DB().Update(func(tx *bolt.Tx) error {
+		return updateStats(tx, profileID, time.Now(), func(c *Counters) {
+			c.Conflicts++
+		})
+	})
+	if err != nil {
+		log.Error(fmt.Sprintf("Error counting sync conflict: %s", err), "Both", log.Fields{Profile: profileID})
+	}
+}
+
+// Daily returns the profile's counters for each of the last number of days, oldest first
+func Daily(profileID string, days int) ([]*Stats, error) {
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1-days)
+	return stats(profileID, start, days, 1)
+}
+
+// Weekly returns the profile's counters for each of the last number of weeks, oldest
+// first.  Weeks start on Monday
+func Weekly(profileID string, weeks int) ([]*Stats, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	return stats(profileID, monday.AddDate(0, 0, -7*(weeks-1)), weeks, 7)
+}
+
+// stats returns count periods of the passed in number of days each, starting at start
+func stats(profileID string, start time.Time, count, days int) ([]*Stats, error) {
+	if count < 1 {
+		return []*Stats{}, nil
+	}
+
+	periods := make([]*Stats, count)
+	for i := range periods {
+		periods[i] = &Stats{Day: start.AddDate(0, 0, i*days).Format(dayFormat)}
+	}
+
+	err := datastore.DB().View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(statsBucket)).Bucket([]byte(profileID))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		for k, v := c.Seek([]byte(periods[0].Day)); k != nil; k, v = c.Next() {
+			day, err := time.ParseInLocation(dayFormat, string(k), start.Location())
+			if err != nil {
+				continue
+			}
+			// round to whole days, to account for daylight saving time changes
+			i := int((day.Sub(start)+12*time.Hour).Hours()/24) / days
+			if i >= count {
+				break
+			}
+
+			counters := &Counters{}
+			err = json.Unmarshal(v, counters)
+			if err != nil {
+				return err
+			}
+			periods[i].add(counters)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// RemoveStats removes all of the counters for the profile
+func RemoveStats(profileID string) error {
+	return datastore.DB().Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(statsBucket))
+		if b.Bucket([]byte(profileID)) == nil {
+			return nil
+		}
+		return b.DeleteBucket([]byte(profileID))
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package activity
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+)
+
+func TestCountersCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		activity Activity
+		expected Counters
+	}{
+		{"upload", Activity{Change: "write", Direction: DirectionUp, Size: 10, Outcome: OutcomeSuccess},
+			Counters{Uploaded: 1, BytesUp: 10}},
+		{"download", Activity{Change: "write", Direction: DirectionDown, Size: 20, Outcome: OutcomeSuccess},
+			Counters{Downloaded: 1, BytesDown: 20}},
+		{"delete", Activity{Change: "delete", Direction: DirectionUp, Outcome: OutcomeSuccess},
+			Counters{Deleted: 1}},
+		{"rename", Activity{Change: "rename", Direction: DirectionDown, Outcome: OutcomeSuccess},
+			Counters{Renamed: 1}},
+		{"create dir", Activity{Change: "createDir", Direction: DirectionUp, Outcome: OutcomeSuccess},
+			Counters{}},
+		{"failure", Activity{Change: "write", Direction: DirectionUp, Size: 10, Outcome: OutcomeFailed},
+			Counters{Failures: 1}},
+	}
+
+	for _, test := range tests {
+		c := Counters{}
+		c.count(&test.activity)
+		if c != test.expected {
+			t.Errorf("%s: counters are %+v, expected %+v", test.name, c, test.expected)
+		}
+	}
+}
+
+func TestStatsPeriods(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-stats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+
+	// Monday June 1st 2015
+	start := time.Date(2015, 6, 1, 0, 0, 0, 0, time.Local)
+	uploads := map[int]int64{ // days after start: uploads
+		-1: 100, // before the first period
+		0:  1,
+		1:  2,
+		6:  4,
+		7:  8,
+		13: 16,
+		14: 32, // after the last weekly period
+	}
+	err = datastore.DB().Update(func(tx *bolt.Tx) error {
+		for day, count := range uploads {
+			err := updateStats(tx, "profile", start.AddDate(0, 0, day).Add(15*time.Hour), func(c *Counters) {
+				c.Uploaded += count
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		count    int
+		days     int
+		expected []int64
+	}{
+		{"daily", 3, 1, []int64{1, 2, 0}},
+		{"weekly", 2, 7, []int64{1 + 2 + 4, 8 + 16}},
+		{"none", 0, 1, []int64{}},
+	}
+
+	for _, test := range tests {
+		periods, err := stats("profile", start, test.count, test.days)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(periods) != len(test.expected) {
+			t.Fatalf("%s: got %d periods, expected %d", test.name, len(periods), len(test.expected))
+		}
+		for i := range periods {
+			day := start.AddDate(0, 0, i*test.days).Format(dayFormat)
+			if periods[i].Day != day {
+				t.Errorf("%s: period %d starts on %s, expected %s", test.name, i, periods[i].Day, day)
+			}
+			if periods[i].Uploaded != test.expected[i] {
+				t.Errorf("%s: period %d has %d uploads, expected %d", test.name, i, periods[i].Uploaded,
+					test.expected[i])
+			}
+		}
+	}
+
+	periods, err := stats("missing", start, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(periods) != 2 || periods[0].Uploaded != 0 || periods[1].Uploaded != 0 {
+		t.Error("A profile without counters has stats")
+	}
+
+	// counters older than the kept days are removed as new ones are added
+	err = datastore.DB().Update(func(tx *bolt.Tx) error {
+		return updateStats(tx, "profile", start.AddDate(0, 0, maxStatsDays+1), func(c *Counters) {
+			c.Uploaded++
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = datastore.DB().View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(statsBucket)).Bucket([]byte("profile"))
+		if b.Get([]byte(start.AddDate(0, 0, -1).Format(dayFormat))) != nil {
+			t.Error("Counters older than the kept days weren't removed")
+		}
+		if b.Get([]byte(start.AddDate(0, 0, 13).Format(dayFormat))) == nil {
+			t.Error("Counters within the kept days were removed")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
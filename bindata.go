@@ -221,7 +221,7 @@ func webFontsGlyphiconsHalflingsRegularWoff2() (*asset, error) {
 	return a, nil
 }
 
-var _webIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x3b\x6b\x8f\xdb\x36\xb6\x9f\x3d\xbf\x82\x51\x71\xef\xa4\x40\x34\x4e\x72\x7b\x8b\x45\xd6\xf6\x6e\x30\x49\xb7\xc1\xa6\x69\x90\xa4\x28\xfa\x65\x01\x5a\xa2\x2d\x76\x64\x51\xa5\x24\x7b\xa6\xae\xff\xfb\x9e\xc3\x97\x48\x49\x7e\xcd\x24\x05\x16\xd8\x29\x1a\x4b\x7c\x9e\xf7\x8b\xd4\xe4\xd1\xab\x1f\xaf\x3f\xfd\xf2\xfe\x35\xc9\xea\x55\x3e\xbb\x98\xe8\x1f\x42\x26\x19\xa3\x29\x3e\xc0\x63\xcd\xeb\x9c\xcd\xbe\x93\x8c\x65\x22\x4f\xc9\xc7\xbb\x22\x99\x8c\x75\xe3\xc5\x68\x34\x9a\xac\x58\x4d\x49\x41\x57\x6c\x1a\xad\x39\xdb\x94\x42\xd6\x11\x49\x44\x51\xb3\xa2\x9e\x46\x1b\x9e\xd6\xd9\x34\x65\x6b\x9e\xb0\x58\xbd\x3c\x21\xbc\xe0\x35\xa7\x79\x5c\x25\x34\x67\xd3\x67\x4f\xc8\x8a\xde\xf2\x55\xb3\x6a\x1b\x9a\x8a\x49\xf5\x46\xe7\xd0\x50\x88\x68\x76\xa1\xf6\x7a\x14\xc7\x84\x96\x25\xa9\x4a\x96\xf0\x05\x4f\x48\x52\x55\x24\x8e\x35\x20\x39\x2f\x6e\x48\x26\xd9\x62\x1a\x41\xf3\x78\x2e\x44\x5d\xd5\x92\x96\x57\x2b\x5e\x5c\x41\x4b\x44\x24\xcb\xa7\x51\x55\xdf\xe5\xac\xca\x18\x03\x30\x57\x2c\xe5\x14\x9a\x12\xc0\xae\x88\x66\x64\x68\x1d\x5e\xa4\xec\xf6\xd4\xf9\x8a\x62\x63\x4d\xbd\xc9\x5c\xa4\x77\xf0\xb3\xa2\xbc\x80\x9f\xb1\xfe\x25\xde\xdf\x05\x22\x54\xb3\x55\x99\xd3\x9a\x55\x88\xc7\x04\x96\xe2\x65\x4d\x78\x3a\x8d\xea\x1f\x60\x42\x44\xea\xbb\x12\x48\x5b\xb3\xdb\x7a\x2c\x69\x52\xf3\x35\x03\x6a\x6c\xb7\x5f\xf1\x05\x01\x6a\xc9\xba\xba\xca\x59\xb1\xac\x33\x32\x23\x4f\x77\xbb\x8b\xd1\x24\xe5\x6b\x92\xe4\xb4\xaa\xa6\x91\x1a\x10\x23\x33\x60\x29\x26\x23\x24\x14\x4c\xd5\xf3\x5e\x70\x1c\xde\x1f\xaf\x97\x8d\xb7\xdb\x2b\xdc\x9a\xfc\xf1\x07\xb9\x4c\x69\xb1\x64\xf2\x72\xb7\x33\x7d\x29\xaf\x56\xbc\xaa\x38\x70\x07\xa8\x22\x80\x47\x7a\x6a\xa4\x39\x31\x6f\xea\x5a\x14\x06\x72\xfd\x12\xd9\x2d\x92\x5c\x54\x30\x49\x14\x71\x92\xf3\xe4\x66\x1a\x99\xb5\x5e\xaa\xf9\x84\x4a\x4e\x63\x60\x3b\x52\xfa\x5a\x0d\x9d\x4d\xaa\x92\x16\xba\x23\xe3\x69\xca\x0a\xa0\x86\x6c\xa0\xe3\x7f\x6b\xbe\x62\xd5\x5f\x27\x63\x1c\x30\x9b\x8c\xf5\x4e\x0a\x84\xd1\x04\x58\x2f\x8a\xe5\x6c\xbb\xcd\x81\x19\xbb\x1d\x0c\xd2\x0d\x44\x31\x19\xa8\xb0\x4d\x41\x70\x79\xbe\x03\xa4\x90\x0a\x63\x20\x83\x26\xcf\x58\x93\x47\xd1\xd2\xb4\xf6\x69\x3a\xa7\xc9\x4d\x2a\x45\x49\x56\x22\x05\x51\x76\xaf\x0b\x9a\x32\x10\x70\x24\x84\x99\x0c\x0b\xf2\x05\x2c\x76\x31\x82\xc7\x59\xfd\x03\x8e\x7f\x2b\x40\xb6\xa1\xcd\x35\x7c\x60\x2b\x51\x33\xd3\xf2\xaa\x91\xb4\xe6\xa2\xc0\x49\x93\xec\xb9\xdd\x18\x45\x20\x4e\x40\xab\x90\x91\x9a\x28\xa6\x67\x99\xdf\x95\x19\x07\x36\x13\xf7\x14\x83\xfc\x4a\x10\x53\xa2\x66\x55\x4d\x92\x30\x90\xe0\x99\xa1\x15\xe9\xa8\x73\xf6\x1c\x44\xcf\xc3\xd1\x97\x18\x2d\x6a\x25\x5d\x32\x32\x9d\x92\x08\xa5\x38\x52\xb4\x69\x72\x3b\xbc\xa0\x6b\xb0\x00\xeb\xb8\xa6\xf3\xca\xca\x03\x3c\xe7\xbc\x42\x89\x50\x3a\x91\x73\xd3\x5e\x02\x54\x80\x83\x42\xd0\x09\x85\x93\x6b\xe4\x1c\x35\xca\xf7\x55\x29\xc5\x82\x83\xae\x19\xa9\x40\xa0\x60\x8d\x0a\xd7\xb0\x1d\x6e\xaf\x88\xa4\xb4\xa6\x71\x2d\x96\x4b\xd7\xd2\xca\x58\x2e\x68\xfa\xde\x4e\x9a\x21\xce\xc4\xbe\x4e\xc6\x74\xa6\xf8\x9f\xf3\x83\xa0\x76\x60\xcb\xc5\xb2\x0f\x97\x6e\x3c\x03\xa6\xb7\x38\x41\x0b\xec\x6b\x29\x85\x24\xd0\x40\x08\x50\xbc\x60\x1b\xd5\x00\x52\x78\x94\xd3\xbc\x58\x88\xb8\xe2\xcb\x42\xf3\x5a\xeb\x2a\xd8\x0d\x34\xd1\xc0\x1b\xb6\x21\x4c\x2d\xe5\xb8\x0f\x12\xa9\x34\x7f\x14\xe0\x7e\x44\x6f\xcb\x26\xcf\x63\xc9\x97\x59\x4d\xe6\x75\x81\xff\x3b\xa9\xf2\x90\x82\xdd\x0c\x61\x0d\xc1\x8e\x01\x5f\xe6\x4d\x0b\xd8\x3b\x80\xd5\x4c\x57\x60\x59\x85\x46\x9b\xda\x80\x63\xc2\x07\x74\x01\x9f\xe8\x1c\x04\xb2\x60\xda\xf6\x43\xa3\x27\xba\x40\xe5\xd8\x78\x1f\x2b\x7b\xd8\xeb\x98\x82\xf3\xf2\xc8\x1f\x8d\x2d\xc4\x48\xa0\x32\xbc\x4e\xbc\x34\x0a\x28\xc9\xcc\x9b\x00\x2f\xea\x5f\xdc\x06\xac\x51\xc5\x52\xc3\xc2\x49\xad\x0d\xff\x48\xbf\x48\xf3\x84\xed\xb3\x77\xe0\x20\xc1\x6d\x66\x7e\xdb\x47\x10\xad\xa6\xea\xb6\x2a\xdb\x40\xde\xd3\x3a\xeb\xf6\xbc\xe2\x92\x25\x28\x8c\xdd\x0e\x6d\x3d\x06\xe7\xa8\x77\x62\x40\x1a\x5b\x98\xb0\xd5\x81\x3a\xa9\xb5\xa3\x1a\x69\xbb\xe8\xd4\xee\x05\x27\x5a\x4c\x14\x32\x96\x00\xdb\xed\xbf\x34\xad\x76\x3b\xfd\xab\xa4\x29\x6a\xf7\x4c\x41\xbe\x30\x1c\x00\xbb\xea\x8d\x9d\x54\x2b\x9a\xe7\xb3\xc7\xbc\xd0\x2d\x5f\x03\xcb\x55\x8b\x9a\x0e\xf0\xa4\xfe\x0a\xe6\x71\xa4\x6d\x4f\xa5\xe8\xa4\xac\x0f\xaa\x2e\x2f\x96\x60\x80\x46\x76\xcc\x71\x19\xb3\xa6\xb0\x2a\x79\xb1\xc7\x1e\x6e\xb7\x7a\x13\x00\x3a\x58\x6e\x4e\xd3\x25\x88\xb2\xed\xbe\x16\x4d\x51\x2b\x67\x82\xb3\x5a\x28\x59\x5e\xb1\x3e\xa0\x19\x38\x1c\xfe\x3b\x88\xc7\x59\xd0\x8a\x9b\xa3\x30\x1e\xdc\xb8\x16\x65\x79\x36\x89\x4a\x0a\x21\x97\xde\x77\x43\x65\x81\xf3\xbf\x20\x6d\x10\xc4\x73\xc9\xe2\x41\x68\x2c\x9c\x03\xf0\x3d\x76\xa5\xed\x8e\xda\xdf\x8e\xac\xcc\xa7\xa1\x6c\xe6\xa8\x5f\xa8\x2a\xc7\xc4\x2e\xb5\xea\x86\x40\x3f\x6d\xa9\x1e\xc2\xea\x46\x81\x1d\x4e\x79\x42\x6b\xb0\xe2\xc3\x42\x58\x81\x2c\xc4\x99\x90\xfc\x77\xf4\xae\xb9\xb3\xd0\xca\x1d\x29\x8d\x23\xb5\x20\x0a\x3c\x42\x8b\x14\xa2\x4d\xd4\x6a\x87\x66\x8f\xa2\x01\x7c\xcf\x1e\x0a\x1f\x05\x1f\xb1\xd1\x06\x7e\x0f\x64\x60\x32\x0c\x4c\x0a\x48\xb5\xb3\x28\xf2\xbb\x3d\x10\x7e\x1e\x80\x72\xb6\x38\x04\x8f\xa6\xd6\x31\x70\x0e\x0b\x84\xc6\x69\x58\x22\xce\x75\x89\x29\x5b\xd0\x26\x57\xef\xf1\x6d\xe0\x19\x21\x53\xa8\x9d\x6b\x7c\x0d\x2f\xce\xbd\x79\x7b\xb6\x16\x1a\xa1\xb6\x86\xd8\xc0\x0e\x9d\xce\x50\xc3\x33\xba\x20\xf3\xac\xe2\xcc\x53\x5d\x9d\xf6\x71\xb9\x8b\x3c\x4e\xf0\x6f\xe6\x1d\x22\x68\x5e\xba\xb7\x4c\xac\x4d\x46\x71\xd8\xf3\xfd\x9c\xb1\x9e\xc3\xfa\x04\xe4\x1c\xf4\x54\x67\x39\x2a\xc4\x21\x74\x52\x21\x63\x37\xb0\x73\x9f\xa5\xdb\x2d\x32\x73\xa8\x1d\xd6\x0b\x9a\x43\x6e\xe0\x6e\xc7\x38\xe1\xc5\x21\x28\x01\x4b\x29\x9a\xd2\x06\x84\xfa\xc5\x60\x72\x48\xac\x3a\xb2\x04\x51\x24\xaf\x70\x8b\x14\x9d\x2f\x1a\x26\x80\xe4\x3d\x84\xe3\xd3\x29\x18\x25\xdb\x67\x84\x3c\x0c\x30\xd5\xb0\xf7\x92\xad\xad\x7b\x3e\x6a\x65\x93\x8c\xad\xc1\x6b\x69\xb5\x6b\x0d\x2c\x2c\x61\x10\x0f\x72\xac\x87\x62\xe1\x52\x57\x6d\x60\x8f\xe3\xf2\x0e\xcc\xbf\xc5\x05\x9f\xc9\xc9\x08\x69\xc3\x16\x98\x86\x00\x19\x97\xff\x8d\x26\x99\x9c\x5d\x78\x5a\xe5\xd2\x38\x63\x76\x5d\x26\xe4\xc5\xba\x28\x17\x98\xb9\x99\x77\x95\xc7\x75\x87\xfb\x06\x60\x68\xbc\x36\x52\x66\x37\x80\x53\x15\x02\x20\xe6\x0d\x4b\x02\x2e\x7b\xdc\x53\x18\xf0\x45\x50\xa5\xa6\x2a\x23\xb5\x3a\x0f\xf3\xd4\x0a\x11\xea\xb0\x2a\x6c\x4c\xa3\xf8\x99\x95\xd0\x94\x53\xa0\x73\x34\x94\x6a\x87\x29\xb0\xce\x79\xcd\xf0\x59\xa7\x86\xa0\x3b\xdb\x10\x7c\x34\xd4\x8d\x4a\xdd\x5a\x8f\x13\xea\x05\x2a\x93\x32\xa5\x02\xb3\xc8\x67\x2c\x16\x64\xdf\x84\xe0\x29\x7f\x03\x89\x22\xcb\xc1\x55\x11\x4a\x74\x4c\xfe\x1d\x24\xcc\x4c\xa2\xeb\x31\x59\xf3\x37\x5d\xd1\xe9\xe1\x89\x66\xc2\x62\xe9\xe7\xd7\x19\x4b\x6e\xe6\xe2\xd6\xe9\xa5\x42\xc2\x59\x23\x5e\x94\x4d\x6d\xc8\xe1\x86\x7a\xda\x50\x65\x62\xf3\xbd\xc2\xee\x1a\x1b\x80\x56\x38\x48\xab\x56\xdb\x87\x51\x39\xf9\x08\xaf\x44\x53\x82\x2c\x14\xfc\x95\x35\x6e\xde\x9e\x2d\x0a\x4a\x2c\x35\xa2\x9f\x24\x63\xc4\x78\xc7\x17\x20\x46\xca\xa7\xef\x8e\x61\xbc\x10\xa2\x3e\x8d\xb3\x7d\x0b\x31\xc0\xe3\xd9\x35\x2d\x12\x96\xdf\xc3\xf0\x94\x92\xaf\xa8\xbc\x0b\xe8\xa6\xf8\xa9\xd5\xc7\x30\x77\xd8\x08\xb8\xba\x8f\xd5\xc6\xe3\x4a\xa9\x73\xb0\xfd\xe5\xba\xa4\x91\x12\x14\xa2\xd5\xf8\x43\x9a\xaa\x89\xfe\x5f\x55\xbd\x87\xaa\x9a\x5c\xf8\x61\xba\x8a\xfc\xca\x39\x10\x45\x15\x61\x5c\x80\xb1\xa7\x52\x6a\x2b\x2e\xbd\x42\xe8\x89\x04\xeb\xd3\x26\x90\xd9\xfa\xf1\xa5\x07\xcc\xe5\x93\x02\xc2\xce\xaf\xef\x49\x3e\x44\x6d\x08\x33\x5f\xff\xc7\xfd\x01\xda\x63\x3f\xd2\x62\x79\xad\xba\x3f\xb2\xda\x46\x43\x0b\x21\x57\x16\x25\x7c\x0e\x92\x1c\x81\x75\xa2\xf9\x8a\xd7\x0a\x95\x0f\xde\x0a\xce\xf8\x79\x64\x55\xd3\xfd\x48\xc9\xda\x46\x30\x5e\x72\x1a\x29\xc3\xf8\xd3\x87\xb7\x2d\x05\x45\x1e\x57\xab\xf8\x39\x31\x45\x38\x4d\xc6\x68\x06\x63\x02\x03\xd7\x31\xbf\x7a\xd6\xb3\xa7\x6e\x93\xd0\xe6\xa2\xf6\x46\x01\x48\x66\x79\xad\x9d\x2d\x14\x65\x4e\x13\x55\x47\x65\x00\xdc\x6b\xac\xcc\x12\x68\x47\xa9\x5b\xd8\x02\x2b\x2f\x20\xf3\x05\x13\x16\x91\x35\xcd\x1b\x86\x26\x5a\xd3\xf7\xaa\x91\xb9\x5f\x38\x69\x59\x10\x3e\x9f\x4b\x9d\x8a\x49\x2c\xbf\x1c\x25\x11\x96\x1a\xdf\x14\xc7\xa9\xf4\xff\x0f\x21\x92\x03\x66\x88\x52\xae\xb3\x47\x19\xe8\xd9\x43\x9a\x73\x20\x2c\x61\xd0\x46\xc8\xf4\x18\x94\xef\xdd\xb8\x01\x28\xdb\xce\x2e\x94\x76\xf9\xcf\xc0\xc4\x3e\x4e\x62\xb1\x00\x75\x51\x5c\xeb\xcb\xea\x81\x48\xa2\x17\x4c\xec\x8f\x27\xfc\xa0\xe1\x86\x97\x9f\xc4\x8d\x89\x19\x80\x31\x0e\x6d\x25\xbf\xe0\x01\x88\x58\x10\x35\xc2\x9a\xd4\x76\xbb\x50\x82\x42\x6e\xa1\xe5\xf0\x16\x77\x63\xca\xe0\x50\xc3\x95\x9c\x7e\xd6\x0f\x8f\xc8\x2f\xa2\x91\xad\x0e\x59\x4a\x93\x0d\xcf\x73\x32\x67\xa4\xaa\x85\x84\x44\x14\xec\xea\x1d\x0e\xcc\x28\xf4\xa5\x12\x7c\xed\xd5\x64\x5c\xfa\x89\xbf\xb7\xa5\x2e\x37\xbe\x59\x90\xa6\x30\x98\x3f\x69\x77\x50\x0b\x2f\x59\xc1\x24\x05\xe7\x41\x61\x0c\xff\xad\x81\x7d\x18\xb8\x6d\x5e\xdf\x01\xd2\x88\xfa\x06\x72\x8a\xcc\x01\x81\x45\x27\x9f\x3c\x0a\x14\x0b\xea\x95\xad\x6f\x7e\x71\xc9\x78\xee\x09\x46\xe0\x6c\xb4\xd9\x3d\x2d\x2e\xea\x18\xe6\x6b\x51\x14\xdd\xe0\x68\x3f\x12\x93\x31\x42\x3e\x1b\x19\x57\xe1\x15\x7f\xce\x8b\xff\x5a\x80\xf4\x91\xc9\xe3\xcb\x8e\xc3\xb9\x04\xb7\x77\x9d\xa1\xb7\x25\xba\x8d\x18\x40\x55\x4d\x3c\x0c\x13\xe4\xe1\x70\x16\x5d\xe5\xce\x79\x37\x57\x20\xfa\xcf\x8e\x6c\x4d\x0c\x7a\x8f\xd0\x16\xbd\x7e\x2f\x40\x6d\xc3\x5d\x75\x50\x6d\x7a\x08\x26\xe2\xbd\xb3\x6a\x9b\xd9\xde\x2f\xfe\x55\x95\x2a\x62\x4e\x1a\xf5\x08\x13\x7e\xa8\x1e\x13\x61\xf5\x72\xea\xcb\x36\x05\xbf\xb4\x43\x4d\xd9\xdc\x8a\xa1\x6e\x34\x14\xb3\x85\x85\x4e\x8c\xac\xc7\x60\x9c\xab\x8c\xd0\x45\x10\x65\xea\x4e\x13\x65\x5e\x8c\x6c\x2c\x14\x42\xa9\x79\x77\xac\x16\xc1\x6e\xa1\x73\xa5\x2a\x95\xea\xbc\xce\x95\x23\x26\xee\x6c\x7a\xbb\x0d\x17\x76\xc7\xd6\x17\x5d\xd5\xea\x1c\xcb\x76\x8a\x11\xf8\x17\x9e\xa6\x75\xcb\xb2\x58\x82\xec\x74\x3b\x2d\x68\x1f\x4d\xe0\x3c\x74\x0e\xae\x09\xe3\x82\x66\xbf\x4b\x8a\x8d\xc9\x26\xba\xd1\x89\xd9\xf0\xdd\x29\x01\x8a\x3e\x32\x6b\x9d\xcb\x80\x2d\xfc\x8b\xd5\xc6\x73\x03\x93\x00\x8e\xc0\xeb\x5b\x29\x7f\x17\x46\x26\xfa\x44\x2b\x3a\x90\x44\xb4\x68\x84\x2e\x39\x70\xc7\xc7\x5d\xb1\x3d\x30\x03\x3f\xfc\x52\x3d\xfe\xed\xa2\xeb\x65\x7b\xba\xec\x6a\x56\x83\x3c\x00\xd5\x55\x95\x1f\x7b\x4f\x66\x7f\x00\x65\xd8\xe5\x5d\x02\x08\xe2\xc5\xb6\x06\x12\x16\x0f\xbc\x05\x15\x7a\xa1\x0f\x3b\x95\x37\x01\x17\xae\x33\x01\x79\xd0\x40\xe5\xa5\x82\xd0\x23\x32\xe7\x99\x23\xc7\x1d\xef\x4c\xc7\xa4\x53\x29\x43\xeb\x34\x67\xe9\xfc\xce\x14\xbe\xbe\xb3\xfc\x1e\xaa\x85\x7a\x70\xc7\x60\x62\xbd\x7a\x59\x77\x9a\x33\xd1\xfb\x6c\x7d\x68\xba\xc3\xa2\xcd\xdb\xb6\x02\xd7\x3a\xed\x63\x66\x43\x97\x6d\x62\x51\xb2\xd6\x62\x10\x93\xef\x3a\xc7\x1c\x38\xea\xb0\xca\xe9\x84\xb5\x7d\x02\x91\x68\x8f\x61\xf6\x8a\xc5\xf3\x41\xb1\x68\x27\xea\xe6\xe0\x5a\x8a\x77\x44\xed\x49\xc8\xa1\xf3\xb4\xc3\x09\xb2\x5a\x36\x9e\x03\x27\x6e\xda\xf9\xe6\x46\x05\xe9\xf9\xc3\xd3\x0e\xd4\x7a\x71\x86\x03\xba\xe5\xf1\xe7\x39\xe1\x0b\x4f\xa2\x42\x26\x1d\x39\xc6\xfb\xe2\x64\xd9\x7b\x9a\xf7\xa5\xc8\xe3\x1f\x30\x1e\x27\xcc\x9f\x4a\x87\xa1\x53\xc4\x2f\x4b\x86\xe0\x7c\x65\x98\x0a\x43\x31\x29\xe8\xad\xe6\xd9\x03\x6d\x79\x50\x24\xfb\xf3\x8c\x79\xaf\x36\x37\x6c\xcd\xfd\x03\xd9\x21\x73\xae\xfb\xcf\xb7\xe7\xc3\xf3\x1e\x64\xd0\x3f\x78\x85\xda\xd3\x2d\x7a\x92\x8b\x26\xed\xda\xf2\x7b\x99\xf2\xf6\x01\x44\x83\xa6\x6b\x4c\x29\x52\x2d\x1c\xc3\xc1\xc0\x40\x15\xcc\x99\x79\x9b\x33\x9d\x94\x84\x54\x2b\x13\xaf\xdb\x5d\x77\x3b\x4f\xd9\x88\xbd\x1b\xa4\x75\xd9\xa3\xe7\xc0\xe1\x9e\xcd\xf6\xec\x4a\x98\xe6\xbd\x34\xcf\x87\x93\x18\x75\x99\xd5\xed\x7f\x06\xd2\xdf\x3a\x9c\xff\x0f\x93\xdd\x05\x00\x52\x83\x78\x56\x22\x6f\xb4\xf7\x82\x76\xc3\x80\x72\xf6\x12\x23\x53\x3d\x44\x24\x90\xc7\x54\x04\x4f\x96\x3d\xcb\xd1\x3a\x17\x63\x57\x32\xba\x06\xfb\xc3\x60\x10\xe4\x7a\x7c\xc1\x19\x56\x18\xea\x8c\x17\x2f\xda\xfa\xc4\x1e\x4d\x23\x1d\x08\x3b\x3a\x57\x34\xab\x39\xd6\x9a\x07\xb5\xae\xad\x50\x19\x78\xed\x4d\xd2\x8f\x0c\xcf\xf2\x2b\xbf\x52\xb5\x4f\x5b\x68\x9a\xa2\x89\x33\x33\xb0\xae\xc1\x68\x92\x11\x01\xd8\xca\x8e\xbd\xf2\x8a\x01\x25\x16\x56\x68\x8f\x4e\x57\x57\x57\xc3\x08\x3b\xde\x0c\xb3\xe7\xd4\xea\x97\x84\x94\x4d\x9c\x5c\xfa\xd2\xa3\xcd\x5d\xf5\x96\x48\x2d\xdb\x51\x2c\x0d\x0d\xbd\x0d\x47\xa3\x1f\xd7\x4c\x6e\x24\x07\xf6\x22\xcf\xb5\xf5\x52\x49\x02\x32\x55\xb5\x41\xfa\x05\x6d\xa2\x60\xa3\xd1\x89\x85\xb1\x3f\x07\x8d\x67\x3e\x1a\x1f\x18\xce\x19\xc4\x81\x12\x75\x68\x50\xd3\x55\x79\x1a\xfc\xc3\xa5\x20\xfd\xa4\x2f\x69\x3a\x59\x90\x0e\x30\xb2\xdf\x6d\x7d\x4b\xba\x27\x07\xad\x82\xbe\x59\x16\x42\x32\xf2\x96\x57\x75\xab\x98\x20\x72\x1f\x6f\x78\x69\x14\x6e\x93\xa1\x87\x29\xc1\x65\x54\x04\x32\xec\x24\x33\xf1\xcd\xb2\xc9\xa9\x24\xec\x16\xaf\xf2\x56\x00\x41\x05\x5a\x99\x8b\x0d\x71\x52\x69\xca\x82\xdf\x9b\x13\x52\xb5\x18\x16\x2c\xf1\x9e\xcb\xfc\x8e\x18\xa3\x15\x14\xf3\x86\x4b\x76\xca\x1a\x2a\x40\x21\x75\xdf\xed\x32\x5a\xc5\xea\xda\x2d\xc1\xa7\x05\x03\xcf\x45\x93\x1b\x7d\x57\xd2\x06\x22\xe1\x8c\xed\x36\x78\xf1\x2e\x55\x1e\x3e\xba\x38\x3b\xf7\xd5\xe4\x1c\x2c\x76\xf7\x09\xe6\x19\x15\x0d\xde\x9b\xa2\x62\xb2\xf6\x2c\x49\x88\xc5\xc9\x3e\x10\xed\x25\x18\x49\x1f\x46\x47\xa6\xc1\x73\xcd\xf0\x56\x97\x57\xdb\xdd\x57\x4e\xb5\xa4\x3a\xed\x2c\xce\xf3\x6b\x7b\xee\x36\x83\x65\x34\xa4\x3b\xdd\xd3\x07\xd7\x9b\xc9\xcb\x34\xdd\xe7\xe5\xbd\x62\x6a\xfb\xd8\xde\xb0\xc7\xab\xf4\x61\x24\xe6\xc8\x6e\x3e\xe3\x50\x66\x83\xf7\xc7\xc7\x60\xb6\x56\x78\x4b\xf3\xaa\xbd\x02\x77\xe6\xa5\xb6\xdb\x4a\x07\x44\xe6\x98\xb3\xa5\x88\xe6\xe1\xd9\x44\xd1\xd3\xba\x1c\xed\x91\x44\x5f\x4a\xd7\xbc\xd6\xa8\xba\xeb\x57\x4d\x3e\xc3\x87\xbd\x31\xc1\xa1\xca\xd8\xc9\x71\x91\xef\x73\x06\x36\xb1\xf1\x39\x7e\x30\xe2\x85\x20\xbe\x09\xc4\xe2\x98\xb6\x79\xfd\xc2\x99\x57\x4d\xb6\xf7\xa1\x54\xfd\xd1\xc4\xfc\xfe\x78\x29\x96\x52\xdd\x04\xee\x99\x1f\xdb\x15\xcf\x41\x69\xfd\x17\x77\x57\xcf\xde\x6b\xb7\x5f\x34\xe8\x21\x30\xc2\xe8\x98\xd2\xee\x42\x6c\xc0\x55\x3c\x7d\xea\xb7\xad\x78\x81\x5e\xd0\x6f\xa1\xb7\x66\x94\xfa\x04\xca\x7c\xdb\xf5\x82\x40\xd3\xff\x58\x52\xbd\xd5\x48\x80\xdf\xdf\x17\xb1\x7a\xe9\xdd\x7d\x4f\x07\x12\x55\x38\x77\x97\x29\xfb\x75\xf4\x63\x12\x70\x92\x1d\xe8\x1b\x80\x0a\xa2\xba\x77\xde\x07\x0e\xf0\x1c\xee\x7a\x1e\x66\x43\x65\x7c\xd8\xc1\x2d\xff\x11\x5e\xfc\xf5\x8f\xab\x2d\x66\x95\xed\x55\xd4\x9e\xba\xa6\x90\x67\xd4\xed\xfa\xaf\xd4\x6b\x88\x81\x27\xd4\xfa\xc9\x17\x67\x2d\xb2\x4a\xa0\xfd\x66\x5d\xbc\xc7\xd6\xa1\x53\x84\xbd\xb7\x66\xda\x53\x99\x13\xae\xb2\xd5\x38\x6c\x86\x97\xe6\xbe\x92\x00\x45\x4f\x91\xcd\x87\x02\x2a\x91\xfa\x27\xbb\xc3\x40\x00\x59\xff\xf7\x1b\xfd\xbc\xdb\xe9\x2e\x74\xea\x4b\xf5\xc1\x8c\x41\x90\x24\x19\xcf\xd3\xce\xc7\x3d\xbf\xd2\x35\xd5\x90\xbe\x58\x0b\x9e\x3e\x7e\xfa\x75\x90\xad\x00\x28\xba\x16\x77\x71\x92\xb5\xd3\xa0\x5d\xe1\x94\xdd\x0e\xb4\xaa\x69\xcf\x26\x72\x7c\x36\x47\x12\x9e\x31\x34\x9f\xe5\x74\x62\xf4\x60\xd5\x63\xe5\xc1\xa3\xf8\x74\x10\xd2\xe4\x51\x6e\x42\x57\xba\x2d\x0c\xc1\xcd\x0f\x63\x9b\x4e\x11\x6e\xe3\x33\x6c\x3a\x88\x7b\xc4\xe0\x8c\x52\x2e\xbb\xb4\xfc\xa9\x7c\xc5\xe5\xa9\xc4\x6c\x4a\x34\x93\x2d\x9e\xff\x10\x04\x42\x2f\x6a\xae\xb6\x19\xb3\x13\x78\x12\xff\x2c\x43\xc9\x2c\x71\x06\xc2\xf0\x44\x8f\x9a\x29\x49\x90\xa6\xc1\xcd\x82\x07\x23\x70\x87\x2f\x81\xd9\xd9\xfb\x64\xb9\x51\xdf\xd5\xc2\xbe\x57\x9d\x7d\xf4\xbe\xf0\x82\xbd\x63\xaf\x53\x7f\xf2\x74\x68\xbb\x7d\x7b\x29\xc7\xa9\x51\x64\xf6\x7c\x2a\xb8\x9a\xbc\x34\xc6\xc1\x93\x7e\x90\x49\x37\xd4\x98\xea\xd6\x9e\xdd\x4b\xd3\xfa\x1a\x76\x2f\x05\xfb\xfc\xfa\x65\xbf\x7a\x7b\x90\x76\x7d\x06\xe5\x6a\xe9\x1c\x0a\x63\x47\x16\x7d\x51\x34\x57\x84\x91\xc1\xbe\x60\x80\x15\x66\x45\x4a\x82\x2f\x89\x9d\xb8\x54\x32\x01\x28\xab\xf1\xaf\xbf\x35\x4c\xde\xc5\xcf\xaf\x9e\xc1\x7f\xf8\x49\xf4\xaf\x3a\x32\x35\xab\x74\x87\x87\x9f\x4f\x1f\x1e\x6b\x64\xef\x84\x91\xfa\x63\xea\xce\x98\xb1\xf9\x50\x7a\xac\x3f\x3f\xff\x77\x00\x00\x00\xff\xff\x3f\x29\x54\x68\x96\x3e\x00\x00")
+var _webIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x3c\x6b\x8f\xdb\x36\xb6\x9f\x3d\xbf\x82\x75\x71\x77\x5a\x20\x1e\x27\xe9\xde\xc5\x22\xeb\xf1\xbd\xc1\xa4\xb9\x2d\x6e\x92\x1d\x24\x59\x14\xfb\x65\x01\xda\xa2\x2d\x76\x64\x51\x4b\x49\xf6\xcc\x7a\xfd\xdf\xef\x39\x3c\x24\x45\x4a\xf2\x6b\x26\xe9\x62\x81\xdb\xa2\x1d\x89\xcf\xf3\x7e\x91\xf2\xe4\x9b\x37\x7f\xbe\xf9\xfc\xd7\xdb\x1f\x59\x5a\xad\xb2\xe9\xc5\x84\xfe\x30\x36\x49\x05\x4f\xf0\x01\x1e\x2b\x59\x65\x62\xfa\x56\x0b\x91\xaa\x2c\x61\x9f\x1e\xf2\xf9\x64\x4c\x8d\x17\x83\xc1\x60\xb2\x12\x15\x67\x39\x5f\x89\xeb\xe1\x5a\x8a\x4d\xa1\x74\x35\x64\x73\x95\x57\x22\xaf\xae\x87\x1b\x99\x54\xe9\x75\x22\xd6\x72\x2e\x46\xe6\xe5\x19\x93\xb9\xac\x24\xcf\x46\xe5\x9c\x67\xe2\xfa\xc5\x33\xb6\xe2\xf7\x72\x55\xaf\x9a\x86\xba\x14\xda\xbc\xf1\x19\x34\xe4\x6a\x38\xbd\x30\x7b\x7d\x33\x1a\x31\x5e\x14\xac\x2c\xc4\x5c\x2e\xe4\x9c\xcd\xcb\x92\x8d\x46\x04\x48\x26\xf3\x3b\x96\x6a\xb1\xb8\x1e\x42\xf3\x78\xa6\x54\x55\x56\x9a\x17\x57\x2b\x99\x5f\x41\xcb\x90\x69\x91\x5d\x0f\xcb\xea\x21\x13\x65\x2a\x04\x80\xb9\x12\x89\xe4\xd0\x34\x07\xec\xf2\xe1\x94\xf5\xad\x23\xf3\x44\xdc\x9f\x3a\xdf\x50\x6c\x4c\xd4\x9b\xcc\x54\xf2\x00\x7f\x56\x5c\xe6\xf0\x67\x4c\x7f\x59\xf0\xcf\x05\x22\x54\x89\x55\x91\xf1\x4a\x94\x88\xc7\x04\x96\x92\x45\xc5\x64\x72\x3d\xac\xde\xc3\x84\x21\xab\x1e\x0a\x20\x6d\x25\xee\xab\xb1\xe6\xf3\x4a\xae\x05\x50\x63\xbb\xfd\x56\x2e\x18\x50\x4b\x57\xe5\x55\x26\xf2\x65\x95\xb2\x29\x7b\xbe\xdb\x5d\x0c\x26\x89\x5c\xb3\x79\xc6\xcb\xf2\x7a\x68\x06\x8c\x90\x19\xb0\x94\xd0\x43\x24\x14\x4c\xa5\x79\xaf\x24\x0e\xef\x8e\xa7\x65\x47\xdb\xed\x15\x6e\xcd\xfe\xf9\x4f\x76\x99\xf0\x7c\x29\xf4\xe5\x6e\x67\xfb\x12\x59\xae\x64\x59\x4a\xe0\x0e\x50\x45\x01\x8f\x68\xea\x90\x38\x31\xab\xab\x4a\xe5\x16\x72\x7a\x19\xba\x2d\xe6\x99\x2a\x61\x92\xca\x47\xf3\x4c\xce\xef\xae\x87\x76\xad\xd7\x66\x3e\xe3\x5a\xf2\x11\xb0\x1d\x29\x7d\x63\x86\x4e\x27\x65\xc1\x73\xea\x48\x65\x92\x88\x1c\xa8\xa1\x6b\xe8\xf8\x5d\x25\x57\xa2\xfc\xd3\x64\x8c\x03\xa6\x93\x31\xed\x64\x40\x18\x4c\x80\xf5\x2a\x5f\x4e\xb7\xdb\x0c\x98\xb1\xdb\xc1\x20\x6a\x60\x86\xc9\x40\x85\x6d\x02\x82\x2b\xb3\x1d\x20\x85\x54\x18\x03\x19\x88\x3c\x63\x22\x8f\xa1\xa5\x6d\xed\xd2\x74\xc6\xe7\x77\x89\x56\x05\x5b\xa9\x04\x44\xd9\xbf\x2e\x78\x22\x40\xc0\x91\x10\x76\x32\x2c\x28\x17\xb0\xd8\xc5\x00\x1e\xa7\xd5\x7b\x1c\xff\x4e\x81\x6c\x43\x9b\x6f\xf8\x28\x56\xaa\x12\xb6\xe5\x4d\xad\x79\x25\x55\x8e\x93\x26\xe9\x4b\xb7\x31\x8a\xc0\x68\x0e\x5a\x85\x8c\x24\xa2\xd8\x9e\x65\xf6\x50\xa4\x12\xd8\xcc\xfc\xd3\x08\xe4\x57\x83\x98\x32\x33\xab\xac\xe7\x73\x01\x12\x3c\xb5\xb4\x62\x2d\x75\x4e\x5f\x82\xe8\x05\x38\x86\x12\x43\xa2\x56\xf0\xa5\x60\xd7\xd7\x6c\x88\x52\x3c\x34\xb4\xa9\x33\x37\x3c\xe7\x6b\xb0\x00\xeb\x51\xc5\x67\xa5\x93\x07\x78\xce\x64\x89\x12\x61\x74\x22\x93\xb6\xbd\x00\xa8\x00\x07\x83\xa0\x17\x0a\x2f\xd7\xc8\x39\x6e\x95\xef\xdb\x42\xab\x85\x04\x5d\xb3\x52\x81\x40\xc1\x1a\x25\xae\xe1\x3a\xfc\x5e\x43\x96\xf0\x8a\x8f\x2a\xb5\x5c\xfa\x96\x46\xc6\x32\xc5\x93\x5b\x37\x69\x8a\x38\x33\xf7\x3a\x19\xf3\xa9\xe1\x7f\x26\x0f\x82\xda\x82\x2d\x53\xcb\x2e\x5c\xd4\x78\x06\x4c\xef\x70\x02\x09\xec\x8f\x5a\x2b\xcd\xa0\x81\x31\xa0\x78\x2e\x36\xa6\x01\xa4\xf0\x28\xa7\x65\xbe\x50\xa3\x52\x2e\x73\xe2\x35\xe9\x2a\xd8\x0d\x34\xd1\xc0\x1b\xb1\x61\xc2\x2c\xe5\xb9\x0f\x12\x69\x34\x7f\x10\xe1\x7e\x44\x6f\x8b\x3a\xcb\x46\x5a\x2e\xd3\x8a\xcd\xaa\x1c\xff\xf3\x52\x15\x20\x05\xbb\x59\xc2\x5a\x82\x1d\x03\xbe\xc8\xea\x06\xb0\x0f\x00\xab\x9d\x6e\xc0\x72\x0a\x8d\x36\xb5\x06\xc7\x84\x0f\xe8\x02\x3e\xf3\x19\x08\x64\x2e\xc8\xf6\x43\x63\x20\xba\x40\xe5\x91\xf5\x3e\x4e\xf6\xb0\xd7\x33\x05\xe7\x65\xc3\x70\x34\xb6\x30\x2b\x81\xc6\xf0\x7a\xf1\x22\x14\x50\x92\x45\x30\x01\x5e\xcc\xff\x71\x1b\xb0\x46\xa5\x48\x2c\x0b\x27\x15\x19\xfe\x01\xbd\x68\xfb\x84\xed\xd3\x0f\xe0\x20\xc1\x6d\xa6\x61\xdb\x27\x10\xad\xba\x6c\xb7\x1a\xdb\xc0\x6e\x79\x95\xb6\x7b\xde\x48\x2d\xe6\x28\x8c\xed\x0e\xb2\x1e\xbd\x73\xcc\x3b\xb3\x20\x8d\x1d\x4c\xd8\xea\x41\x9d\x54\xe4\xa8\x06\x64\x17\xbd\xda\xbd\x92\x8c\xc4\xc4\x20\xe3\x08\xb0\xdd\xfe\x8d\x68\xb5\xdb\xd1\x5f\x23\x4d\xc3\x66\xcf\x04\xe4\x0b\xc3\x01\xb0\xab\xc1\xd8\x49\xb9\xe2\x59\x36\xfd\x4e\xe6\xd4\xf2\x3d\xb0\xdc\xb4\x98\xe9\x00\x4f\x12\xae\x60\x1f\x07\x64\x7b\x4a\x43\x27\x63\x7d\x50\x75\x65\xbe\x04\x03\x34\x70\x63\x06\x27\x9b\xc2\xb2\x90\xf9\x1e\x7b\xb8\xdd\xd2\x26\x00\x74\xb4\xdc\x8c\x27\x4b\x10\x65\xd7\x7d\xa3\xea\xbc\x32\xce\x04\x67\x35\x50\x8a\xac\x14\x5d\x40\x53\x70\x38\xf2\x1f\x20\x1e\x67\x41\xab\xee\x8e\xc2\x78\x70\xe3\x4a\x15\xc5\xd9\x24\x2a\x38\x84\x5c\xb4\xef\x86\xeb\x1c\xe7\x7f\x45\xda\x20\x88\xe7\x92\x25\x80\xd0\x5a\x38\x0f\xe0\x2d\x76\x25\xcd\x8e\xe4\x6f\x07\x4e\xe6\x93\x58\x36\x33\xd4\x2f\x54\x95\x63\x62\x97\x38\x75\x43\xa0\x9f\x37\x54\x8f\x61\xf5\xa3\xc0\x0e\x27\x72\xce\x2b\xb0\xe2\xfd\x42\x58\x82\x2c\x8c\x52\xa5\xe5\x3f\xd0\xbb\x66\xde\x42\x1b\x77\x64\x34\x8e\x55\x8a\x19\xf0\x18\xcf\x13\x88\x36\x51\xab\x3d\x9a\x1d\x8a\x46\xf0\xbd\x78\x2a\x7c\x1c\x7c\xc4\x86\x0c\xfc\x1e\xc8\xc0\x64\x58\x98\x0c\x90\x66\x67\x95\x67\x0f\x7b\x20\xfc\x32\x00\x65\x62\x71\x08\x1e\xa2\xd6\x31\x70\x0e\x0b\x04\xe1\xd4\x2f\x11\xe7\xba\xc4\x44\x2c\x78\x9d\x99\xf7\xd1\x7d\xe4\x19\x21\x53\xa8\xbc\x6b\xfc\x11\x5e\xbc\x7b\x0b\xf6\x6c\x2c\x34\x42\xed\x0c\xb1\x85\x1d\x3a\xbd\xa1\x86\x67\x74\x41\xf6\xd9\xc4\x99\xa7\xba\x3a\xf2\x71\x99\x8f\x3c\x4e\xf0\x6f\xf6\x1d\x22\x68\x59\xf8\xb7\x54\xad\x6d\x46\x71\xd8\xf3\xfd\x92\x8a\x8e\xc3\xfa\x0c\xe4\xec\xf5\x54\x67\x39\x2a\xc4\x21\x76\x52\x31\x63\x37\xb0\x73\x97\xa5\xdb\x2d\x32\xb3\xaf\x1d\xd6\x8b\x9a\x63\x6e\xe0\x6e\xc7\x38\x11\xc4\x21\x28\x01\x4b\xad\xea\xc2\x05\x84\xf4\x62\x31\x39\x24\x56\x2d\x59\x82\x28\x52\x96\xb8\x45\x82\xce\x17\x0d\x13\x40\x72\x0b\xe1\xf8\xf5\x35\x18\x25\xd7\x67\x85\x3c\x0e\x30\xcd\xb0\x5b\x2d\xd6\xce\x3d\x1f\xb5\xb2\xf3\x54\xac\xc1\x6b\x91\xda\x35\x06\x16\x96\xb0\x88\x47\x39\xd6\x53\xb1\xf0\xa9\x2b\x19\xd8\xe3\xb8\x7c\x00\xf3\xef\x70\xc1\x67\x76\x32\x42\x64\xd8\x22\xd3\x10\x21\xe3\xf3\xbf\xc1\x24\xd5\xd3\x8b\x40\xab\x7c\x1a\x67\xcd\xae\xcf\x84\x82\x58\x17\xe5\x02\x33\x37\xfb\x6e\xf2\xb8\xf6\xf0\xd0\x00\xf4\x8d\x27\x23\x65\x77\x03\x38\x4d\x21\x00\x62\xde\xb8\x24\xe0\xb3\xc7\x3d\x85\x81\x50\x04\x4d\x6a\x6a\x32\x52\xa7\xf3\x30\xcf\xac\x30\x44\x1d\x36\x85\x8d\xeb\xe1\xe8\x85\x93\xd0\x44\x72\xa0\xf3\xb0\x2f\xd5\x8e\x53\x60\xca\x79\xed\xf0\x69\xab\x86\x40\x9d\x4d\x08\x3e\xe8\xeb\x46\xa5\x6e\xac\xc7\x09\xf5\x02\x93\x49\xd9\x52\x81\x5d\xe4\x0b\x16\x0b\xd2\xdf\xc7\xe0\x19\x7f\x03\x89\xa2\xc8\xc0\x55\x31\xce\x28\x26\x7f\x0b\x09\xb3\xd0\xe8\x7a\x6c\xd6\xfc\xfb\xb6\xe8\x74\xf0\x44\x33\xe1\xb0\x0c\xf3\xeb\x54\xcc\xef\x66\xea\xde\xeb\xa5\x41\xc2\x5b\x23\x99\x17\x75\x65\xc9\xe1\x87\x06\xda\x50\xa6\x6a\xf3\x93\xc1\xee\x06\x1b\x80\x56\x38\x88\x54\xab\xe9\xc3\xa8\x9c\x7d\x82\x57\x46\x94\x60\x0b\x03\x7f\xe9\x8c\x5b\xb0\x67\x83\x82\x11\x4b\x42\xf4\xb3\x16\x82\x59\xef\xf8\x0a\xc4\xc8\xf8\xf4\xdd\x31\x8c\x17\x4a\x55\xa7\x71\xb6\x6b\x21\x7a\x78\x3c\xbd\xe1\xf9\x5c\x64\x8f\x30\x3c\x85\x96\x2b\xae\x1f\x22\xba\x19\x7e\x92\xfa\x58\xe6\xf6\x1b\x01\x5f\xf7\x71\xda\x78\x5c\x29\x29\x07\xdb\x5f\xae\x9b\xd7\x5a\x83\x42\x34\x1a\x7f\x48\x53\x89\xe8\xff\xaf\xaa\x8f\x50\x55\x9b\x0b\x3f\x4d\x57\x91\x5f\x99\x04\xa2\x98\x22\x8c\x0f\x30\xf6\x54\x4a\x5d\xc5\xa5\x53\x08\x3d\x91\x60\x5d\xda\x44\x32\x5b\x7d\x77\x19\x00\x73\xf9\x2c\x87\xb0\xf3\xfb\x47\x92\x0f\x51\xeb\xc3\x2c\xd4\xff\x71\x77\x00\x79\xec\x6f\x48\x2c\x6f\x4c\xf7\x27\x51\xb9\x68\x68\xa1\xf4\xca\xa1\x84\xcf\x51\x92\xa3\xb0\x4e\x34\x5b\xc9\xca\xa0\xf2\x31\x58\xc1\x1b\xbf\x80\xac\x66\x7a\x18\x29\x39\xdb\x08\xc6\x4b\x5f\x0f\x8d\x61\xfc\xcb\xc7\x77\x0d\x05\x55\x36\x2a\x57\xa3\x97\xcc\x16\xe1\x88\x8c\xc3\x29\x8c\x89\x0c\x5c\xcb\xfc\xd2\xac\x17\xcf\xfd\x26\xb1\xcd\x45\xed\x1d\x46\x20\xd9\xe5\x49\x3b\x1b\x28\x8a\x8c\xcf\x4d\x1d\x55\x00\x70\x3f\x62\x65\x96\x41\x3b\x4a\xdd\xc2\x15\x58\x65\x0e\x99\x2f\x98\xb0\x21\x5b\xf3\xac\x16\x68\xa2\x89\xbe\x57\xb5\xce\xc2\xc2\x49\xc3\x82\xf8\xf9\x5c\xea\x94\x42\x63\xf9\xe5\x28\x89\xb0\xd4\xf8\x73\x7e\x9c\x4a\xff\xf9\x14\x22\x79\x60\xfa\x28\xe5\x3b\x3b\x94\x81\x9e\x3d\xa4\x39\x07\xc2\x02\x06\x6d\x94\x4e\x8e\x41\x79\xeb\xc7\xf5\x40\xd9\x74\xb6\xa1\x74\xcb\x7f\x01\x26\x76\x71\x52\x8b\x05\xa8\x8b\xe1\x5a\x57\x56\x0f\x44\x12\x9d\x60\x62\x7f\x3c\x11\x06\x0d\x77\xb2\xf8\xac\xee\x6c\xcc\x00\x8c\xf1\x68\x1b\xf9\x05\x0f\xc0\xd4\x82\x99\x11\xce\xa4\x36\xdb\xc5\x12\x14\x73\x0b\x2d\x47\xb0\xb8\x1f\x53\x44\x87\x1a\xbe\xe4\xf4\x0b\x3d\x7c\xc3\xfe\xaa\x6a\xdd\xe8\x90\xa3\x34\xdb\xc8\x2c\x63\x33\xc1\xca\x4a\x69\x48\x44\xc1\xae\x3e\xe0\xc0\x94\x43\x5f\xa2\xc1\xd7\x5e\x4d\xc6\x45\x98\xf8\x07\x5b\x52\xb9\xf1\xe7\x05\xab\x73\x8b\xf9\xb3\x66\x07\xb3\xf0\x52\xe4\x42\x73\x70\x1e\x1c\xc6\xc8\xbf\xd7\xb0\x8f\x00\xb7\x2d\xab\x07\x40\x1a\x51\xdf\x40\x4e\x91\x7a\x20\xb0\xe8\x14\x92\xc7\x80\xe2\x40\xbd\x72\xf5\xcd\xaf\x2e\x19\x2f\x03\xc1\x88\x9c\x0d\x99\xdd\xd3\xe2\xa2\x96\x61\xbe\x51\x79\xde\x0e\x8e\xf6\x23\x31\x19\x23\xe4\xd3\xc1\x45\xa7\xf8\x73\x5e\xfc\xd7\x00\x44\x47\x26\xdf\x5d\xb6\x1c\xce\x25\xb8\xbd\x9b\x14\xbd\x2d\xa3\x36\x66\x01\x35\x35\xf1\x38\x4c\xd0\x87\xc3\x59\x74\x95\x3b\xef\xdd\x7c\x81\xe8\xdf\x3b\xb2\xb5\x31\xe8\x23\x42\x5b\xf4\xfa\x9d\x00\xb5\x09\x77\xcd\x41\xb5\xed\x61\x98\x88\x77\xce\xaa\x5d\x66\xfb\xb8\xf8\xd7\x54\xaa\x98\x3d\x69\xa4\x11\x36\xfc\x30\x3d\x36\xc2\xea\xe4\xd4\x97\x4d\x0a\x7e\xe9\x86\xda\xb2\xb9\x13\x43\x6a\xb4\x14\x73\x85\x85\x56\x8c\x4c\x63\x30\xce\x35\x46\xe8\x22\x8a\x32\xa9\xd3\x46\x99\x17\x3e\x16\x8a\xa1\xbc\x38\xa9\xb8\x22\xee\xa1\x73\x65\x2a\x95\xe6\xbc\xce\x97\x23\x26\xfe\x6c\x7a\xbb\x8d\x17\xf6\xc7\xd6\x17\x6d\xd5\x6a\x1d\xcb\xb6\x8a\x11\x54\x20\x09\x4f\xd3\xda\x65\x59\x2c\x41\xb6\xba\xbd\x16\x34\x8f\x36\x70\xee\x3b\x07\x27\xc2\xf8\xa0\x39\xec\xd2\x6a\x63\xb3\x89\x76\x74\x62\x37\xfc\x70\x4a\x80\x42\x47\x66\x8d\x73\xe9\xb1\x85\x7f\x74\xda\x78\x6e\x60\x12\xc1\x11\x79\x7d\x27\xe5\x1f\xe2\xc8\x84\x4e\xb4\x86\x07\x92\x88\x06\x8d\xd8\x25\x47\xee\xf8\xb8\x2b\x76\x07\x66\xe0\x87\x5f\x9b\xc7\xff\xba\x68\x7b\xd9\x8e\x2e\xfb\x9a\x55\x2f\x0f\x40\x75\x4d\xe5\xc7\xdd\x93\xd9\x1f\x40\x59\x76\x05\x97\x00\xa2\x78\xb1\xa9\x81\xc4\xc5\x83\x60\x41\x83\x5e\xec\xc3\x4e\xe5\x4d\xc4\x85\x9b\x54\x41\x1e\xd4\x53\x79\x29\x21\xf4\x18\xda\xf3\xcc\x81\xe7\x4e\x70\xa6\x63\xd3\xa9\x44\xa0\x75\x9a\x89\x64\xf6\x60\x0b\x5f\x6f\x1d\xbf\xfb\x6a\xa1\x01\xdc\x23\x30\xb1\x41\xbd\xac\x3d\xcd\x9b\xe8\x7d\xb6\x3e\x36\xdd\x71\xd1\xe6\x5d\x53\x81\x6b\x9c\xf6\x31\xb3\x41\x65\x9b\x91\x2a\x44\x63\x31\x98\xcd\x77\xbd\x63\x8e\x1c\x75\x5c\xe5\xf4\xc2\xda\x3c\x81\x48\x34\xc7\x30\x7b\xc5\xe2\x65\xaf\x58\x34\x13\xa9\x39\xba\x96\x12\x1c\x51\x07\x12\x72\xe8\x3c\xed\x70\x82\x6c\x96\x1d\xcd\x80\x13\x77\xcd\x7c\x7b\xa3\x82\x75\xfc\xe1\x69\x07\x6a\x9d\x38\xc3\x03\xdd\xf0\xf8\xcb\x9c\xf0\xc5\x27\x51\x31\x93\x8e\x1c\xe3\x7d\x75\xb2\xec\x3d\xcd\xfb\x5a\xe4\x09\x0f\x18\x8f\x13\xe6\x37\xa5\x43\xdf\x29\xe2\xd7\x25\x43\x74\xbe\xd2\x4f\x85\xbe\x98\x14\xf4\x96\x78\xf6\x44\x5b\x1e\x15\xc9\x7e\x3b\x63\xde\xa9\xcd\xf5\x5b\xf3\xf0\x40\xb6\xcf\x9c\x53\xff\xf9\xf6\xbc\x7f\xde\x93\x0c\xfa\xc7\xa0\x50\x7b\xba\x45\x9f\x67\xaa\x4e\xda\xb6\xfc\x51\xa6\xbc\x79\x00\xd1\xe0\xc9\x1a\x53\x8a\x84\x84\xa3\x3f\x18\xe8\xa9\x82\x79\x33\xef\x72\xa6\x93\x92\x90\x72\x65\xe3\x75\xb7\xeb\x6e\x17\x28\x1b\x73\x77\x83\x48\x97\x03\x7a\xf6\x1c\xee\xb9\x6c\xcf\xad\x84\x69\xde\x6b\xfb\x7c\x38\x89\x31\x97\x59\xfd\xfe\x67\x20\xfd\x07\x8f\xf3\x0f\x98\xec\x2e\x00\x90\x0a\xc4\xb3\x54\x59\x4d\xde\x0b\xda\x2d\x03\x8a\xe9\x6b\x8c\x4c\x69\x88\x9a\x43\x1e\x53\x32\x3c\x59\x0e\x2c\x47\xe3\x5c\xac\x5d\x49\xf9\x1a\xec\x8f\x80\x41\x90\xeb\xc9\x85\x14\x58\x61\xa8\x52\x99\xbf\x6a\xea\x13\x7b\x34\x8d\xb5\x20\x6c\xe9\x5c\x5e\xaf\x66\x58\x6b\xee\xd5\xba\xa6\x42\x65\xe1\x75\x37\x49\x3f\x09\x3c\xcb\x2f\xc3\x4a\xd5\x3e\x6d\xe1\x49\x82\x26\xce\xce\xc0\xba\x86\xe0\xf3\x94\x29\xc0\x56\xb7\xec\x55\x50\x0c\x28\xb0\xb0\xc2\x3b\x74\xba\xba\xba\xea\x47\xd8\xf3\xe6\x69\xd5\x2f\x0d\x29\x9b\x3a\xb9\xf4\x45\xa3\xed\x5d\xf5\x86\x48\x0d\xdb\x51\x2c\x2d\x0d\x83\x0d\x07\x83\x3f\xaf\x85\xde\x68\x09\xec\x45\x9e\x93\xf5\x32\x49\x02\x32\xd5\xb4\x41\xfa\x05\x6d\x2a\x17\x83\xc1\x89\x85\xb1\xdf\x06\x8d\x17\x21\x1a\x1f\x05\xce\xe9\xc5\x81\x33\x73\x68\x50\xf1\x55\x71\x1a\xfc\xfd\xa5\x20\x7a\xa2\x4b\x9a\x5e\x16\xb4\x07\x8c\xed\x77\x5b\x7f\x60\xed\x93\x83\x46\x41\x7f\x5e\xe6\x4a\x0b\xf6\x4e\x96\x55\xa3\x98\x20\x72\x9f\xee\x64\x61\x15\x6e\x93\xa2\x87\x29\xc0\x65\x94\x0c\x32\xec\x79\x6a\xe3\x9b\x65\x9d\x71\xcd\xc4\x3d\x5e\xe5\x2d\x01\x82\x12\xb4\x32\x53\x1b\xe6\xa5\xd2\x96\x05\x7f\xb2\x27\xa4\x66\x31\x2c\x58\xe2\x3d\x97\xd9\x03\xb3\x46\x2b\x2a\xe6\xf5\x97\xec\x8c\x35\x34\x80\x42\xea\xbe\xdb\xa5\xbc\x1c\x99\x6b\xb7\x0c\x9f\x16\x02\x3c\x17\x9f\xdf\xd1\x5d\x49\x17\x88\xc4\x33\xb6\xdb\xe8\x25\xb8\x54\x79\xf8\xe8\xe2\xec\xdc\x97\xc8\xd9\x5b\xec\xee\x12\x2c\x30\x2a\x04\xde\xcf\x79\x29\x74\x15\x58\x92\x18\x8b\x8b\xd3\x2f\x65\xae\x14\x18\xc9\x10\x46\x4f\xa6\xde\x73\xcd\xf8\x56\x57\x50\xdb\xdd\x57\x4e\x75\xa4\x3a\xed\x2c\x2e\xf0\x6b\x7b\xee\x36\x83\x65\xb4\xa4\x3b\xdd\xd3\x47\xd7\x9b\xd9\xeb\x24\xd9\xe7\xe5\x83\x62\x6a\xf3\xd8\xdc\xb0\xc7\xab\xf4\x71\x24\xe6\xc9\x6e\x3f\xe3\xa0\x2f\x57\xba\xe3\x47\x60\xb6\x56\x78\x4b\xf3\xaa\xb9\x02\x77\xe6\xa5\xb6\xfb\x92\x02\x22\x7b\xcc\xd9\x50\x84\x78\x78\x36\x51\x68\x5a\x9b\xa3\x1d\x92\xd0\xa5\x74\x1b\x0b\x9b\x3d\xfc\xf5\xab\x3a\x9b\xe2\xc3\x9e\x50\xe8\x11\x31\x00\x95\x19\xde\xab\x5c\x42\xdc\x1e\x19\x99\x37\xa2\xc2\x44\x7b\x6e\xca\xce\x41\x42\x49\xa6\x02\x4c\x44\xe8\xe3\xf6\xb8\xb8\xf3\x3c\xdc\x01\xcf\xd0\x76\x0c\x87\xfc\x82\xbd\xed\x63\x30\xda\xe3\xd8\x6e\x55\x96\xc9\x7c\x89\xe7\x28\xb9\xa8\x36\x4a\xdf\x41\xbc\x52\xe7\x55\xf9\x8c\xd0\x7b\x28\x41\x76\x98\x58\x43\x9a\x55\x52\x04\xb0\x91\xa5\xb8\xe8\xf7\x0f\x7b\x4f\xe9\xbe\x3c\x0e\xa1\x57\x7b\x9d\x6d\xf8\x43\xc9\xea\x12\x11\xe9\x40\xfd\xaf\x87\xf5\x65\x17\xd6\x82\xc8\x7e\x02\x6c\xbd\x76\x21\x74\xb2\x24\x8b\x2b\xda\xf5\x90\x7b\x0d\x64\xdd\x7d\x2d\x40\x50\x44\xc2\x7e\x83\x75\xc8\xb0\x34\xb0\xb0\x81\x02\x2c\xee\x34\x00\x08\xab\x1f\x5e\xf5\x8a\xfc\xa1\x30\xf6\xf4\x28\x76\x25\x73\x14\x53\xd6\xce\x07\x09\xde\x4e\x28\x7b\x62\x24\xdb\x9f\x48\x39\xff\xff\x1c\x4f\xf3\x4a\x83\xb9\x44\x4f\xb8\x46\x0d\xd7\x6a\x65\x5a\x40\x51\x2b\xd8\xb9\x34\xe2\x15\x86\x02\x21\x27\x2c\xc1\x2c\x6f\x2d\x2b\xce\xb6\x49\x41\x32\xf6\xc3\xf4\x3d\xf4\x30\x7b\xde\x75\xab\x55\xe5\x2a\x6b\x01\xc3\x7e\xc2\xc3\xcb\x04\xf2\xc7\x0a\xa0\xc7\x34\x44\xb9\x50\xb5\x24\xa6\x41\x10\x26\x35\x9d\x39\x50\xd6\xb2\xc2\x50\xaa\x82\x45\x5f\x9d\x63\xb5\x7a\x38\xfb\xc3\x23\x12\x14\xc3\x5a\xbc\xc5\xe4\x79\xbb\x82\x51\x84\xe2\x5b\xba\xea\x7c\x72\x86\xf2\x96\xbe\xde\xda\x97\x91\xfc\xb6\xc0\xdf\x0a\x8d\xc5\xa8\x33\xc0\xb7\x33\x30\xc1\x5a\xfc\x4b\x30\x79\xde\x8b\xc8\x2f\x32\x4f\xd4\xe6\xf1\xf9\xe2\xc6\xcc\xdf\x87\xcc\x41\xc5\x73\x77\xe7\x33\xb9\x92\xd5\x33\x23\xcd\xa3\x17\xac\xaa\x75\x8e\xfd\xdc\x76\x00\xc1\x16\xfb\x74\x10\xd1\xb0\x76\x0a\x0f\xe8\xac\xc2\xb4\x75\xf1\xc8\xc9\xd9\xc9\xaa\x1a\xe6\xa4\x3d\x85\x09\x57\xbf\xc3\x0f\x4a\x83\x12\x45\x08\x2f\x1e\x9e\x11\x74\xdd\x83\xb5\xe0\xb4\xd9\xdd\x97\x36\xe7\x93\xb6\x26\x18\x8e\xd7\x6a\xa9\xcd\x97\x42\x1d\x6d\x76\x5d\xa3\x19\x04\xf5\xe1\x8b\xbf\xcb\xef\xbe\x7b\x73\x5f\x3c\xd2\x10\x18\x61\x63\x70\x23\x22\xb9\xda\x80\xd3\x7d\xfe\x3c\x6c\x73\x32\x14\xb4\xf0\x7b\x3b\xca\x7c\x22\x6d\xbf\xfd\x7e\xc5\xa0\xe9\x3f\x1c\xa9\xde\x11\x12\x10\x33\xed\xab\x68\x05\xe5\xdf\xc7\xde\x1e\x98\x9b\x83\x75\xff\xb1\x45\xf7\x9c\xfd\x98\x04\x9c\x94\x27\x74\x13\x84\x92\xaf\xc5\x87\xe0\x03\x48\x78\x8e\x77\x3d\x0f\xb3\xbe\x63\x7e\xd8\xc1\x2f\xff\x09\x5e\xc2\xf5\x8f\x87\xf5\x58\x75\x6e\x3e\x55\xe9\x84\xf3\xe4\x47\xfc\xfa\x6f\xcc\xeb\x13\x76\x70\xd7\x7a\xe2\x8c\x81\xeb\x3b\xb7\x05\x32\xde\xe6\xc2\xef\xa1\xd9\x58\x01\x7b\x4f\xa0\xa7\xb2\x46\xb7\x97\x19\x47\x6b\x20\x4b\x77\x02\x7f\x59\x9a\x2a\x32\x08\xb0\xaa\x4a\xac\x6c\x8f\x70\x87\x98\xee\x81\x2a\xd2\x53\xa8\x84\xa4\x68\x46\x0d\xc3\x66\xba\x92\x80\xad\x7d\x77\x23\xf6\xde\x05\x6e\xee\x9a\x9c\x70\x41\xbf\xc2\x61\x53\xfc\x14\xe0\x5b\x04\xbf\x63\x7e\xec\xe7\x8f\xa6\x3c\xfc\xbf\xe2\x01\xcb\x1b\x28\xb0\xff\x7d\x47\xcf\xbb\x1d\x75\x61\xa9\x62\x69\x3e\x03\xb6\x08\x82\x0d\x94\x59\xd2\xfa\x64\xf9\x57\xbe\xe6\x04\xe9\xab\xb5\x92\xc9\x77\xcf\xbf\x8f\x6a\xb0\x00\x0a\x9d\x30\x9e\x76\x97\x81\x40\xbb\xc2\x29\xbb\x1d\xd8\x82\xba\xb9\x71\x91\xe1\xb3\xbd\x68\x11\x38\x02\xfb\xb1\x71\xcb\x93\x44\xab\x1e\x3b\xf4\x3c\x8a\x4f\x0b\x21\x22\x8f\x49\x7e\xe9\xfc\xde\xc1\x10\xdd\x67\xb5\x16\xf5\x14\x95\xb4\x99\xb0\x2b\x72\xe3\x1e\x23\xf0\x81\x89\xd4\x6d\x5a\xfe\xa5\x78\x23\xf5\xa9\xc4\xac\x0b\x34\xee\x0d\x9e\xff\xa3\x18\x38\x78\x6e\x45\xde\x1a\xcb\x28\x3f\x0e\x6f\x68\x18\x99\x65\xde\xac\x59\x9e\xd0\xa8\xa9\x91\x04\x6d\x1b\xfc\x2c\x78\xb0\x02\x77\xf8\x6a\xbb\x9b\xbd\x4f\x96\x6b\xf3\x6b\x21\xb0\xef\x55\x6b\x1f\xda\x17\x5e\xb0\x77\x1c\x74\xd2\x87\xdc\x87\xb6\xdb\xb7\x97\x29\x07\x10\x8a\xc2\xdd\xba\x89\x3e\xb8\x5a\x5a\x93\x16\x48\x3f\xc8\xa4\x1f\x6a\x1d\x4c\x63\x85\x1f\xa5\x69\x5d\x0d\x7b\x94\x82\x7d\x79\xfd\x72\xdf\xf2\x3f\x49\xbb\xbe\x80\x72\x35\x74\x8e\x85\xb1\x25\x8b\xa1\x28\xda\x0f\x9f\x90\xc1\xa1\x60\x80\x15\x16\x60\xfe\xa3\xdf\x47\xf1\xe2\x52\xea\x39\x40\x59\x8e\x7f\xfd\x7b\x0d\xe9\xe8\xe8\xe5\xd5\x0b\xf8\x17\x7f\xe8\xe5\x57\xaa\xb7\xd9\x55\xda\xc3\xe3\x1f\x85\x39\x3c\xd6\xca\xde\x09\x23\xe9\x27\x62\x5a\x63\xc6\xf6\xe7\x5f\xc6\xf4\xa3\x3a\xff\x07\x2a\x05\xff\xe4\x6c\x47\x00\x00")
 
 func webIndexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -236,7 +236,7 @@ func webIndexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/index.html", size: 16022, mode: os.FileMode(436), modTime: time.Unix(1452698581, 0)}
+	info := bindataFileInfo{name: "web/index.html", size: 18284, mode: os.FileMode(436), modTime: time.Unix(1792392559, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -261,7 +261,7 @@ func webJsBootstrapMinJs() (*asset, error) {
 	return a, nil
 }
 
-var _webJsIndexJs = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xec\x3c\x5f\x6f\xdb\x38\xf2\xcf\xcd\xa7\x60\xb4\x45\x21\x23\xae\x9c\xee\x0f\xbf\x17\x77\xb3\xc0\x5e\x12\xec\xe5\xae\xdb\x06\x4d\xfa\x94\xe6\x00\x45\xa2\x6d\x35\xb2\x64\x48\x72\xd2\x60\x2f\xdf\xfd\x66\xf8\x47\xa2\xa8\xa1\xa4\x34\x49\x51\x2c\x96\x0f\x6e\x4c\x0e\x87\xc3\xf9\xc7\x99\x21\xdd\xd9\x8c\x1d\xe6\x9b\xbb\x22\x59\xae\x2a\xf6\xf3\xfe\x9b\xff\x67\xe7\xc9\x9a\x9d\xad\xc2\x2c\xcb\xb3\x80\xfd\x96\xa6\x4c\x8c\x95\xac\xe0\x25\x2f\x6e\x78\x1c\xec\xc0\x9c\x4f\x25\x67\xf9\x82\x55\xab\xa4\x64\x65\xbe\x2d\x22\xce\xa2\x3c\xe6\x0c\xbe\x2e\xf3\x1b\x5e\x64\x3c\x66\x57\x77\x30\xce\xd9\x1f\x27\xe7\x2c\x4d\x22\x9e\x95\x1c\x67\x56\xab\xb0\x62\x51\x98\xb1\x2b\xce\x16\xf9\x36\x8b\x59\x92\x09\xb8\x77\x27\x87\xc7\xef\xcf\x8e\xd9\x22\x49\x79\xb0\xb3\xf3\xd2\x8f\xf3\x68\xbb\xe6\x59\x35\x09\x0a\x1e\xc6\x77\xfe\x62\x9b\x45\x55\x92\x67\xfe\x84\xfd\xb9\xc3\xa0\x7d\x0c\xe1\xfb\x0d\x0f\x8e\x8e\xff\xf1\xe9\x77\x76\xc0\x16\x61\x5a\xf2\xb7\x3b\x62\xec\x26\x2c\x58\x01\x7d\x19\xbf\xd5\x70\xbe\x9c\x85\x8d\xa7\x73\xe6\xad\xc3\x24\xf3\xa6\x75\x5f\xc5\xd7\x9b\x34\xac\x38\x8c\xfc\x54\xfd\xd1\x1e\x8b\xc3\x2a\x9c\xb3\x66\x3e\xb6\x30\xe5\x45\x55\xce\xd9\xc5\xe5\xb4\xd5\xbf\x09\x97\xbc\x8b\x1d\x5b\x9a\x2f\x4f\xc5\xe0\x7e\xd3\x7f\x2f\xff\xbc\x9f\x00\xdd\x3b\x12\x28\x8c\x4f\x8b\x1c\xb9\x50\xfa\xd0\xab\xfb\xde\xe5\x4b\xf1\x5d\x74\x14\x7c\x01\xe2\x58\x9d\x55\x61\xb5\x2d\xb9\xd1\x1f\x00\x7b\x1a\x32\xbd\x30\x8e\x7f\x43\x32\xbd\x39\xab\xb9\x57\xdd\x6d\xf8\x94\xa5\xc0\xd2\x29\x8b\x79\x15\x26\xe9\xc4\xda\x59\xb2\x60\xfe\x2e\x82\xd9\x03\x82\x4d\xd0\x0f\x7c\xf5\xe2\x30\x5b\xf2\xc2\x7b\xdb\x02\xb8\xdf\xe9\x22\xc2\x95\x28\x44\xd8\x8f\x88\x7e\xcb\x18\x2f\x8a\xbc\x60\x79\x14\x6d\x8b\x82\xc7\xbb\x1d\xa4\xe6\xb7\x22\xd8\x6c\xcb\x95\xef\x49\xf6\x7b\x53\x07\x89\x73\xf1\x39\x25\x57\x9d\xcb\xdd\x77\xc6\x24\x37\xe6\xea\xdf\x36\x0d\x93\x36\x4d\x2f\x7d\xef\x2a\x8f\xef\xbc\x49\x00\x2c\x3e\x4c\xc3\xb2\xf4\xbd\x75\x1e\x87\xe9\xeb\x7c\xc3\x33\xcf\x80\xbe\x6f\xd6\xf1\xe2\xa4\x5c\x27\x65\xd9\x11\x09\xbf\x41\x35\xb7\x76\x52\x04\xe5\x06\xed\x46\x8e\x06\xd7\xfc\x6e\x13\x56\x2b\xd1\x59\xf9\x5e\xe0\x4d\x2e\xf6\x2f\xa7\x4c\x0e\x26\x59\xcc\xbf\x06\xc9\x94\xbd\xb1\xe8\x44\x33\x90\x9c\x02\x56\x17\xc1\x92\x57\x35\xe7\x2c\x48\x94\x95\x1c\x09\x52\x9e\x2d\xab\x15\x3b\x38\x38\x60\xfb\x94\xe4\x9a\xcd\x17\x7c\x0d\xd6\xde\xbb\x7f\xc1\x03\x92\x1b\x5a\xab\x4d\x4e\xd8\xcb\x21\xfd\x59\x7e\xab\x0c\xf9\x08\xcc\xd3\xc7\x8f\x00\xfa\xfc\x89\xb5\xca\x2d\x70\x21\xbf\x0d\xd2\x3c\x0a\xd3\xb3\x2a\x2f\xc0\xd4\x82\x92\x57\x27\x60\xd8\xbe\x07\xf6\xc2\x57\x79\x1a\xbf\x2e\xef\xb2\xe8\x35\x10\x5c\x81\x35\xa6\x79\x7e\x0d\x1a\x04\xc8\x82\x2a\xff\xd7\xd9\x87\xf7\x1d\x9c\xa6\xe5\xd1\x7b\x10\x26\xfd\x9e\x7f\x1d\x23\x50\xd0\x15\x5f\x4f\xf1\xba\xc2\x1a\xb9\xd8\x69\xc1\x6f\xc6\x68\xcf\xf6\xaa\x2a\xc0\xf3\xf5\xad\x88\x42\x57\x5a\xa1\x81\x26\xec\x17\x5a\xea\x05\x32\x53\xc0\x6d\x24\xb2\x7d\xa7\x94\xc7\x6d\xa6\xf1\x73\x7d\x1a\x40\xf9\x43\x1b\x17\x8f\x93\x4a\xc1\x0c\x32\xe6\x65\x10\x7e\x09\xbf\xfa\xdd\xfd\x61\x93\x9e\xc3\x03\x86\x78\x5d\xf7\x80\x6d\x5b\xe0\xc9\x31\xdb\xc8\xc5\x66\x0e\x28\x3c\x2e\xce\x25\xaa\x2f\x65\x6e\x1f\x03\x26\xd4\x9c\xa1\xda\x05\x65\x55\x24\xd9\x32\x59\xdc\x29\x63\x8f\xf2\xac\x02\x95\x9a\x74\x27\xde\x4f\x3a\x5d\x41\x9c\x67\xbc\x39\x1d\xe1\x60\xd8\xa6\x9d\x6d\xeb\xa6\xa4\x28\x3c\x6d\x56\x33\x6d\x2a\xcc\x4b\x7d\x53\x18\x02\xa4\xcf\xb6\x07\x0b\x4d\xb2\xcc\xf2\x82\x9f\xc0\xd1\x0e\x0e\x6d\xca\x3c\xdb\xf0\x2d\x70\xa5\x38\x2d\x79\x11\x33\xa8\x3d\x2e\xc0\x23\x8f\xdd\xa3\x38\x4e\x34\x0c\x85\xfe\xed\x0e\xa9\x46\xc0\x03\x42\x8b\x08\xcb\x1a\xe4\xa0\xcd\xb6\x91\xec\xb2\xd8\x64\xd0\xe3\x50\x7c\x88\xa3\x22\x9e\x8e\x27\x5a\x63\x16\xc1\x89\x03\x67\x19\xde\x80\x3f\xa3\x38\x41\xda\x13\x7a\x68\x65\x0e\xe0\xa5\x5b\xea\x4b\x6e\x0e\xed\x19\x74\x1d\xa8\xa8\x8a\x2d\xb7\x18\xa0\x10\x05\x8a\x06\x7f\x50\xd9\x07\xd4\xdc\xb5\xdf\x5e\xba\x44\x18\xe9\x00\x76\x79\xa3\x9a\x91\x8f\x54\xdd\x07\x11\x43\x6a\x62\xa0\x78\x78\x8c\x46\x00\x08\x94\x31\xc3\x3f\x9b\x1c\x14\x4f\xb8\x9b\x35\x2f\x4b\xe0\x8c\xc3\x36\xea\xbf\x2d\xa5\x18\xab\x11\x63\x04\xdd\xd2\x13\x21\xee\xbf\x65\xfd\xa3\xc8\x3a\xe6\x29\xaf\x46\x4b\xbb\x2d\x49\x39\xf7\xdb\x65\x39\xc4\x73\x83\x15\x43\xf2\xfe\x0e\xa7\x48\xfd\xb7\xc1\xbd\x2a\x5f\x2e\x53\x7e\x94\x14\x5c\x2c\x32\xc8\x3f\x0c\xc1\x2c\x1e\xea\xb9\x10\x89\xfd\x4c\x51\xe7\x00\xdf\xdb\xb3\xe2\x31\x48\x73\x21\x4d\x1f\x3d\x1f\xdc\xf7\x7e\x7f\xe6\x85\x7c\x6f\x65\x23\x6c\x8f\x79\x0d\x02\x6f\xea\x42\xed\xf2\x2a\xab\xfc\xf6\x1d\x86\xeb\x7f\x60\xea\x30\x1c\xba\xf9\xde\x4f\x69\x03\x3e\x09\x44\xc6\xe1\x0b\x34\x8e\xa3\xb4\x04\x7d\x8c\xaa\x7f\x4b\x6a\xa9\x23\x17\x42\xbd\x53\x18\xf2\x3d\x1c\x2c\xf2\x1c\x88\x5f\x25\x69\x5c\xb8\x93\x38\x5c\xed\x23\xe4\x3d\x15\x1f\x47\xb5\xb6\xdc\x34\x81\x41\x6d\xa9\xd9\x36\x4d\xbf\x91\x62\x05\x57\x08\x12\x0e\x05\xd6\x33\x8c\x57\x49\xf7\x81\xa7\x73\xa4\xb2\x27\x09\xab\xe3\x7d\xcb\x93\x48\xf2\x3c\x3b\x70\x41\xed\x8c\x02\x88\x7a\x29\x3d\x8c\x50\xd0\x19\x50\x6b\x5b\xaa\x55\x06\x40\xb9\x15\x06\xc7\x9c\x82\x6b\xd9\x11\x24\x6c\x22\x97\x1c\x13\x80\x20\x20\x8a\xb1\x8e\x40\x4c\xfd\x14\x58\x6c\x1e\x4a\x33\xf5\xf5\x44\x67\x5e\x54\x03\x50\x0c\xd0\xca\xd3\xd6\x7a\x5c\x78\x4a\xd0\x51\x2b\x96\x6d\x26\x92\xf7\xa3\x72\x66\xe4\x8a\xd4\x90\xb1\x7a\x67\xeb\x53\x8b\xae\x1e\xde\x7f\xda\x80\x0f\x1b\xc5\x7c\xb4\x9a\xa6\xba\x20\x6c\x48\xac\x05\xc8\x5f\xbc\x78\x21\xe2\x43\xf8\x7a\xb8\x0a\x0b\xd1\x21\x78\x8b\x40\xa2\x68\xf1\x61\xe1\x7b\x9f\x3f\x43\xd6\xf9\x2b\x7b\xfd\x06\xb1\x03\xc4\x0b\x0d\x8f\xe5\x21\x18\x14\xd3\x6a\x6f\x66\x03\xcc\xd4\xf8\x0e\x7e\x6a\x5a\x10\xbf\xac\x94\x68\x50\x4a\xc2\x08\xa5\x8a\x1d\xbf\x1c\xb0\x37\x64\xd6\xcb\xab\x6d\x91\xf5\xaa\xb7\xdc\x71\xbe\xb1\xad\x40\xd4\x2e\x20\x90\x96\x7a\x29\xa0\xbe\xe4\x49\xd6\x47\x51\x0d\x7e\x70\x80\x86\x4f\xd0\xd3\x20\x6c\x98\xda\xa6\x8d\xd0\x81\x46\x26\x53\x8d\xc0\xe1\x54\x10\x30\x0b\xd7\x7c\x14\xa0\x30\x2c\x32\xbc\xd3\x86\xa1\x70\x8c\x76\xad\x42\x57\xc5\x89\x30\x54\x14\xd2\x86\x55\xeb\x5d\x5b\xcf\x69\xaa\x2d\x9f\x27\xce\x92\x53\xc9\x16\x89\xc5\x34\x57\x89\xa7\x5b\xf7\x23\x8f\xa0\x55\x12\x3b\x93\x34\x49\x99\x3c\x32\xbe\xc7\xb6\xa4\xab\x7d\xf8\xbe\x48\x17\xdd\xbf\x31\xb5\x2b\x79\xb2\x8c\x0c\x15\xf3\x22\x59\x26\x59\x98\x42\xf0\x2a\x3a\x8e\xf8\x22\x84\x10\x8b\xb2\x9e\xc8\xce\x28\x95\xab\xec\x42\x42\xb0\xd6\xf0\xcc\x3c\x6a\x09\x23\x43\xd8\x57\xaf\x70\x4a\xe3\x82\x4e\xb2\x9b\x30\x4d\x62\xb6\x85\xd4\x9c\x85\x59\xcc\x66\x2c\x47\xbf\x55\x96\xb7\x79\x11\x83\x25\xee\x1e\x80\x7f\xc2\x69\x11\x1c\x1d\xd7\x3c\xa3\xcf\x43\x31\x84\x4e\xa9\xb7\x84\xdd\x3a\x37\x9d\x31\xc6\x3f\x93\x38\xe6\x19\xb0\x36\xba\x1e\xe4\xec\xc3\xa3\x98\x30\x8e\x4f\x44\x35\x62\x74\xfc\xd2\x56\x32\x59\xca\x00\x1e\xd3\xc1\x8c\xf2\x7d\x72\x89\x46\x32\xad\x02\x08\x21\x9a\xdd\x7a\xce\x78\x5f\x6c\x7e\xab\x8a\x3b\x62\x9e\x38\xa4\xf8\x92\x7f\xd5\xf7\x41\x7c\x79\xfc\x75\xe3\x37\x6b\xd9\x71\x73\x14\x56\xd1\x0a\x14\xa5\xa7\x0c\xda\xc3\x8e\x5a\x99\x3e\xe2\x9a\xbb\x54\x6e\xe2\x38\x55\x3a\x2c\x94\x68\x8d\xda\x3d\xb9\x6c\x87\x93\x72\x96\xbc\x28\x71\xed\x72\x44\x49\xca\xd4\x17\x59\xe9\x1f\xa9\x32\x48\x7a\x69\xc7\x62\xc6\xcd\x45\x9b\x92\x52\x1f\x9e\x36\x7d\xf2\xfa\xa3\x94\xa7\x26\xce\xeb\xbd\xee\x68\xdd\xa4\x49\x5c\x9a\xca\xba\x2e\xa7\xf2\x65\x93\x5e\xa1\x76\x44\x3f\x36\xbc\xde\x0c\x92\x98\x30\x68\x31\x82\x07\xa5\x6b\xac\x27\xab\x12\xe3\xe0\x01\x16\xb0\x3d\x70\xa0\x65\x9e\x6e\x47\x00\x1e\x6d\x8b\x10\xc1\xce\x38\xf4\xc4\xa5\x03\x5a\xde\x74\xc2\x20\x1e\xca\xc4\x78\xa2\x0d\xf2\xc2\xf3\x67\x9f\x3f\x07\xff\xfd\x0f\x7c\xfc\xf9\xe6\x3e\xd8\x7b\x39\xf1\x2e\x89\x09\xf5\x19\xe9\xda\x68\x73\xda\xb8\x20\xa4\x3b\x6e\x27\x21\xa6\xdc\xa8\x34\xb5\xe1\xbc\x2e\x09\x26\xb1\x5b\x02\x1a\x06\xbf\x0e\xc8\x42\x83\xd6\x7d\x63\x65\xa3\x27\x76\x07\x1f\x24\x34\x1b\x8d\x05\xd1\x27\x52\x3d\x55\x76\xf4\x09\x77\xd3\x72\x0f\x03\x52\xdd\xd8\xd1\xd0\x90\x90\x37\x9d\x38\x63\xa4\xd0\x37\xad\x5c\xb3\x75\xf6\xd5\x7f\xcd\x66\x6b\x5e\xad\xf2\xb8\xdc\x69\xa1\x53\x05\x61\xbc\xe4\x77\x85\x4f\x03\x8c\x7f\xbf\x5d\x5f\xf1\xc2\xef\x83\x9a\x8c\xd5\x05\x0a\x57\x03\x60\x3b\x5a\xe1\xeb\xdd\xf7\x4e\xea\xce\xe9\xf4\xc3\xd9\x39\x71\x51\x34\x7c\xe1\x34\x7c\xd9\x44\x5e\x34\x21\xf1\xd6\xfd\x52\xab\xa4\xf5\xb6\x2b\x80\xbf\x32\xf7\x3f\xfd\xc8\xcc\x97\x55\xd5\xbf\x30\xfb\x8f\x8e\xdf\x1d\x9f\x1f\xff\xc0\x12\x80\x58\x49\x3e\xb6\xe9\x13\xc2\x98\xab\xe5\xdf\x8f\x29\x45\x23\xb6\x5a\x8a\xe5\x9e\xe1\x86\x99\x26\x0f\x9b\x97\xc4\x10\xda\xa9\xa3\x97\x84\xba\x7f\x86\x1b\x69\xe3\x02\xd1\x08\x74\x75\x8f\xeb\x26\x07\xc3\x36\x0d\xe3\xc2\x8c\x6d\x01\x69\x9c\x2f\x42\x69\x11\x31\xc1\x3f\xbf\xd4\xab\xa9\xda\x0f\x74\xee\xed\xf5\xe1\xb0\xd7\xbb\x48\x2e\x45\x6c\x72\xa0\x79\x35\x34\x19\x9b\x39\xb9\xd4\xaa\x64\xdc\xb4\xab\x4e\x7a\xb7\xfd\x88\x0e\xf3\xad\x38\x6a\x4d\x6c\x11\xf6\x0d\x23\xd3\x77\x29\x9a\x23\x1e\xdb\x63\x10\x57\x1b\x6b\x38\xf8\xdf\x42\x42\x64\x33\x76\xbb\x77\x8e\xd2\x23\xdd\xde\xfb\xe0\x0a\xe2\x7e\x69\xb8\x5d\x4b\xbd\xb7\x22\x7e\x15\x72\xa8\x50\xc3\x8e\xf7\xbb\xdd\xd8\x84\x34\xc1\x0c\x5d\x81\xac\x28\x0e\x38\xc6\x74\xa9\xc0\x35\x4e\x55\x06\xdc\x91\xaf\x24\x42\x52\x89\x5f\xdc\xc4\x68\x18\xf8\xd6\x4f\x95\x02\xd4\x3d\x3d\x24\x2a\x48\xf1\xf5\x2d\x11\xa2\x09\x60\x30\xd2\x73\x05\x5f\xdb\x39\x86\xdf\x9d\xea\xc1\x53\x44\x3f\x32\xd2\x9c\x09\x8a\x9e\xf0\x0c\xa0\x8d\x16\x77\x31\x17\x9f\xb4\x53\x95\xec\x91\x7e\x92\x70\x86\xe3\xcf\x14\x55\x04\xb2\x4f\x94\x6e\x51\xc4\x90\xce\xab\x57\x6c\x57\x57\x06\xaf\x93\x8d\x10\x81\x47\xde\x4d\xb4\xa4\xe4\xb8\xf4\x11\x95\xde\x49\xd7\x7f\x63\x7b\x90\x0f\xaf\x17\xd4\x3a\x64\xba\x22\x4b\x91\xc8\x79\x3d\xe6\xd3\x81\x75\x5c\x3a\xe9\xd6\xf2\x13\xf4\xd6\x1e\x72\x0d\x8c\x8d\xbc\xc2\x7b\xd8\x65\xbb\xa0\x6c\x7c\x25\xc8\xfc\xfa\x24\x2f\xd6\x94\x09\x61\x6d\xf0\xbb\xc6\x14\xa6\xb5\xd0\x08\x9f\x23\xa6\x30\x6e\x29\xc8\x17\xc2\xba\x49\x5b\x37\x94\x95\x26\x11\x1b\x96\xb0\x46\x82\xe2\xbd\xc8\x5c\x54\x60\xdc\x30\x63\xf8\x62\x55\xc4\x74\xd3\xc5\x5e\x93\x16\xbb\xea\x3b\x15\x88\xfb\x1f\x50\x74\x6f\x90\x89\x9b\x1c\x6b\xce\xe0\x2d\xb6\xa0\xbc\xd7\x02\xbf\xe5\x8d\xcc\x63\x2c\x6f\x30\x6e\x68\x07\x0e\xed\x17\x28\x06\x59\x43\x47\x18\x6d\x82\x8f\x4d\x60\x2c\x4b\x18\x6d\x05\x56\x60\xd7\x70\x4d\xbc\xea\xec\x72\x49\xdc\x87\x1a\x10\x0e\x79\x50\xe1\xb4\xe9\xeb\x47\x46\xd4\x22\xe6\x57\xc5\x21\xe2\xd5\x69\x7f\xd8\xb9\x69\xb2\x31\xe7\x19\xd0\xd5\x82\x5e\xae\x8e\x55\xc9\x9e\x37\x41\xda\xb9\xdb\x81\x68\xfd\x0e\xda\xfe\x41\xc5\xa3\xf4\x29\xcd\x97\xcf\x1e\x08\xc9\x1f\xb2\xd8\xcf\xc3\x69\x67\xd5\xf7\x93\x8b\x6e\x4c\xf4\x6d\x2a\x8d\x4a\x03\x64\x58\x79\x13\xe1\x24\xc9\x57\xed\xe2\xd7\x0c\x18\x3f\x21\x0a\x7d\xeb\xff\x2b\xfd\xd4\x1d\xdb\x6c\x16\xad\x78\x74\x8d\xc8\x16\x49\x51\x56\x38\x0f\x7f\xde\x04\x2a\x0b\x81\x77\xb5\x0a\x41\xb4\xa1\xea\xc6\xdf\x11\x38\x73\x5b\x84\x7a\x07\x00\xe6\x6f\x18\xa8\x9f\x2a\x2c\x87\x7f\xaa\xe0\x7a\x8d\xdd\x2c\xb3\x34\x57\xc1\x9d\x5e\xec\x5f\x06\xb7\x2b\x9e\xb9\x0e\x13\xe4\x95\x9e\xfa\x6b\x4d\xeb\x88\x60\x08\x56\x11\xfe\xb8\xec\x3f\x37\x9c\x8f\xd0\x7a\xd0\xf5\xbd\x83\xa4\x2c\xbb\xd3\x45\x79\x29\x43\xec\xbd\xee\x49\x30\x2d\x91\x4c\xeb\x30\x53\xf5\x4f\x20\xa6\x15\xaf\x14\xf8\x99\xb0\x21\xf2\x75\x68\x97\xac\xe6\x17\x14\xb8\x4b\xfc\xc7\x96\xca\xf3\xfa\xa5\xb6\x63\xd2\x61\x44\x9c\x14\xf2\x8d\x86\xba\xa7\x9b\xb2\x6e\x76\x8c\xbc\x54\x89\xf1\x4c\x28\xed\xcc\x08\xd2\xc5\x1b\x31\x32\xa1\xd6\x53\x54\xf0\x69\xa6\xbe\x3b\xed\x84\x5c\x11\x31\xf2\x0d\x53\xe7\x4d\xdc\x13\x84\xc8\x48\xeb\x9e\x0c\xa3\xfa\x02\xe4\x07\x84\xbe\x6a\x4f\x73\xfd\xc7\x70\x30\x28\xff\xfd\x3b\x4c\x6e\xda\x38\xce\xd0\xae\x82\x8c\x93\x6d\x25\x27\x0c\xf7\xfb\xbd\x1b\xc6\x66\x27\x80\x86\x65\x3c\x22\x42\x80\x8f\x67\x8e\x0e\xc6\x69\xf7\x90\xfc\x9e\x32\x36\xd0\x15\xe4\x8b\x4b\xe2\xac\x7b\x82\xc0\x55\xac\x21\xae\xd6\x93\xf5\x19\x9c\x94\xab\x6e\xc8\x8a\x6f\x13\xbb\x2f\x1e\xe5\x9b\xc7\x45\xcf\x83\x47\xfa\xc9\xa3\xf5\xe8\x91\x7c\xf6\x88\x0f\x1f\x5d\xc4\xaa\xdb\xf1\x85\xfd\x12\x92\x7a\xac\xa8\x9b\x51\x1e\x6d\x15\x9b\xea\x07\x49\x74\xb5\xc9\x9c\x8e\xcb\x42\xd8\x81\xf5\x71\x7c\xb4\x31\x50\x1b\xc7\x97\x5d\x49\x66\x3f\x56\x30\x9b\xab\x40\x4c\x27\x28\xa2\x8c\x2d\x5e\xbe\x0c\xf9\x33\x77\x89\x0f\x5b\xc7\x91\x81\x84\x1f\xef\xa7\x06\xf2\x12\x6c\xd2\x29\xd7\x7e\x4a\xde\x70\xd8\x67\xfa\xb7\x85\x09\x16\xea\x1e\xff\x35\xef\x4b\xb2\x29\x33\xee\x06\x1d\x64\x36\xd4\xd8\x8e\xf5\xa6\x1c\x15\x07\xba\x02\x8c\x40\x4f\xb4\x9d\xcc\x54\xdc\x2e\x06\x64\xc4\xfe\xba\xfb\x4c\x57\x95\x98\x11\xa8\x14\x2f\x86\xf6\xa7\xf6\x0c\xaa\x8c\xdd\x4c\xa3\x13\xb7\xce\x6f\xec\xad\x78\x68\xdc\x9d\x55\xdf\x3d\xd5\x63\xee\xa6\xa8\x0c\xba\xff\xd6\xa6\x27\x6d\xbe\x27\xd8\xa3\x52\x14\x98\x73\x9e\xac\x79\xbe\xad\x7c\x8b\x21\x53\xf6\x7f\xfb\xfb\xfb\xae\xe8\x52\x9e\x82\xf0\x69\x0b\x19\xcf\xae\x7c\x21\x1f\x6c\xa2\x87\x90\xe7\x4c\xc7\x4d\x14\x01\x64\x5d\xdc\x6f\xfe\x07\x83\x69\xfd\x5f\x0e\x60\xfc\x37\x45\x04\x43\x27\x29\x95\x7a\xc8\x87\xa2\xf8\xf6\xd3\xd4\xec\x36\x22\xf5\x48\x34\x58\x85\xe5\x87\xdb\x0c\xb8\xbb\x01\x02\xee\x20\x31\x03\x33\xdb\x16\x28\x5f\x4a\x22\x94\x34\x11\x8b\x9e\x35\xe6\x88\x19\xb3\xeb\x1a\x21\x66\x23\x3d\x65\xaf\xb6\x84\x5d\x69\xd8\xa8\x15\xe9\x55\x6c\xad\x01\x1d\x40\xbb\xff\x5f\x00\x00\x00\xff\xff\x76\x47\xb3\x8c\x52\x44\x00\x00")
+var _webJsIndexJs = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xed\x1c\x5d\x6f\xdc\x38\xee\xb9\xf3\x2b\x14\x6f\x51\xd8\x88\xeb\x49\xf7\x70\x2f\x49\x53\xa0\xd7\xe6\x76\x73\xd7\x8f\xa0\x49\x71\x0f\x69\x0e\x70\xc7\x9a\x19\x6f\x3c\xd6\xc0\xf6\x24\x0d\xf6\xf2\xdf\x8f\x94\xe4\x2f\x99\xb6\x35\xf9\xe8\xf5\x80\xe6\x61\x92\x91\x28\x8a\xa2\x48\x8a\xa4\xa8\x4c\xa7\xec\x8d\x58\xdf\x64\xf1\x62\x59\xb0\x5f\xf7\x5e\xfc\x95\x9d\xc5\x2b\x76\xba\x0c\xd3\x54\xa4\x01\x7b\x9d\x24\x4c\xf6\xe5\x2c\xe3\x39\xcf\xae\x78\x14\x4c\xa6\x53\xf6\x39\xe7\x4c\xcc\x59\xb1\x8c\x73\x96\x8b\x4d\x36\xe3\x6c\x26\x22\xce\xe0\xeb\x42\x5c\xf1\x2c\xe5\x11\xfb\x7a\x03\xfd\x9c\xbd\x3f\x3e\x63\x49\x3c\xe3\x69\xce\x71\x64\xb1\x0c\x0b\x36\x0b\x53\xf6\x95\xb3\xb9\xd8\xa4\x11\x8b\x53\x09\xf7\xee\xf8\xcd\xd1\x87\xd3\x23\x36\x8f\x13\x1e\x4c\x26\x4f\xdd\x48\xcc\x36\x2b\x9e\x16\x5e\x90\xf1\x30\xba\x71\xe7\x9b\x74\x56\xc4\x22\x75\x3d\xf6\xe7\x84\xc1\xcf\xa7\x10\xbe\x5f\xf1\xe0\xed\xd1\xdf\x3e\xff\xc6\x0e\xd9\x3c\x4c\x72\x7e\x30\x91\x7d\x57\x61\xc6\x32\x68\x4b\xf9\x75\x09\xe7\xaa\x51\xf8\xc3\x93\x7d\xe6\xac\xc2\x38\x75\xfc\xaa\xad\xe0\xab\x75\x12\x16\x1c\x7a\x7e\x29\xde\xb7\xfb\xa2\xb0\x08\xf7\x59\x3d\x1e\x7f\xc2\x84\x67\x45\xbe\xcf\xce\x2f\xfc\x56\xfb\x3a\x5c\xf0\x2e\x76\xfc\x49\xc4\xe2\x44\x76\xee\xd5\xed\xb7\xea\xcf\x5b\x0f\xe8\x9e\x28\xa0\x30\x3a\xc9\x04\x72\x21\x77\xa1\xb5\x6c\x7b\x27\x16\xf2\xbb\x6c\xc8\xf8\x1c\xb6\x63\x79\x5a\x84\xc5\x26\xe7\x8d\xf6\x00\xd8\x53\x93\xe9\x84\x51\xf4\x1a\xc9\x74\xf6\x59\xc5\xbd\xe2\x66\xcd\x7d\x96\x00\x4b\x7d\x16\xf1\x22\x8c\x13\xcf\x58\x59\x3c\x67\xee\x0e\x82\x99\x1d\x92\x4d\xd0\x0e\x7c\x75\xa2\x30\x5d\xf0\xcc\x39\x68\x01\xdc\x4e\xba\x88\x70\x26\x0a\x11\xb6\x23\xa2\xd7\x29\xe3\x59\x26\x32\x26\x66\xb3\x4d\x96\xf1\x68\xa7\x83\xb4\xf9\x2d\x0b\xd6\x9b\x7c\xe9\x3a\x8a\xfd\x8e\xdf\x43\xe2\xbe\xfc\xf4\xc9\x59\xf7\xd5\xea\x3b\x7d\x8a\x1b\xfb\xfa\x77\x9b\x06\xaf\x4d\xd3\x53\xd7\xf9\x2a\xa2\x1b\xc7\x0b\x80\xc5\x6f\x92\x30\xcf\x5d\x67\x25\xa2\x30\x79\x2e\xd6\x3c\x75\x1a\xd0\xb7\xf5\x3c\x4e\x14\xe7\xab\x38\xcf\x3b\x5b\xc2\xaf\x50\xcc\x8d\x95\x64\x41\xbe\x46\xbd\x51\xbd\xc1\x25\xbf\x59\x87\xc5\x52\x36\x16\xae\x13\x38\xde\xf9\xde\x85\xcf\x54\x67\x9c\x46\xfc\x5b\x10\xfb\xec\x85\x41\x27\xaa\x81\xe2\x14\xb0\x3a\x0b\x16\xbc\xa8\x38\x67\x40\xe2\x5e\xa9\x9e\x20\xe1\xe9\xa2\x58\xb2\xc3\xc3\x43\xb6\x47\xed\x5c\xbd\xf8\x8c\xaf\x40\xdb\x07\xd7\xdf\xde\xc1\x26\x37\x4a\xa9\x6e\x72\xc2\x9c\x0e\xe9\x4f\xc5\xb5\x56\xe4\xb7\xa0\x9e\x2e\x7e\x04\xd0\xe6\x7a\xc6\x2c\xd7\xc0\x05\x71\x1d\x24\x62\x16\x26\xa7\x85\xc8\x40\xd5\x82\x9c\x17\xc7\xa0\xd8\xae\x03\xfa\xc2\x97\x22\x89\x9e\xe7\x37\xe9\xec\x39\x10\x5c\x80\x36\x26\x42\x5c\x82\x04\x01\xb2\xa0\x10\xff\x38\xfd\xf8\xa1\x83\xb3\xa9\x79\xf4\x1a\xa4\x4a\x7f\xe0\xdf\x6c\x36\x14\x64\xc5\x2d\x87\x38\xdd\xcd\xb2\x9c\xec\x24\xe3\x57\x36\xd2\xb3\xf9\x5a\x64\x60\xf9\x86\x66\xc4\x4d\xd7\x52\x51\x02\x79\xec\x25\xbd\xeb\x19\x32\x53\xc2\xad\x15\xb2\x3d\x6f\x48\x4f\xc7\x17\x53\xdb\xb9\x21\x09\xa0\xec\xa1\x89\x8b\x47\x71\xa1\x61\x46\x19\xf3\x34\x08\xff\x08\xbf\xb9\xdd\xf5\xd5\x96\xc3\x01\x86\x38\x3e\x09\xb0\xc9\xf0\xe4\x98\xae\xd5\x64\xd3\x1e\x28\x3c\x2e\xce\x14\xaa\x3f\x72\x91\x0e\x40\xed\x33\x14\xbb\x20\x2f\xb2\x38\x5d\xc4\xf3\x1b\xad\xec\x33\x91\x16\x20\x52\x5e\x77\xe0\xad\xd7\x69\x0a\x22\x91\xf2\xfa\x74\x84\x83\x61\x93\x14\xd4\x0e\x36\x76\x51\x5a\xda\xb4\x62\x9a\x2f\xd5\x4b\x7f\xd3\x18\x02\xa4\xcf\xd4\x07\x03\x4d\xbc\x48\x45\xc6\x8f\xe1\x68\x07\x83\xe6\x33\xc7\x19\x06\xd7\x82\xd3\xda\x2f\x62\x04\xb5\xc6\x39\x58\x64\xdb\x35\xca\xe3\xa4\x84\xa1\xd0\x1f\x4c\x48\x31\x02\x1e\x10\x52\x44\x68\xd6\x28\x07\x4d\xb6\x59\xb2\xcb\x60\x53\x83\x9e\x1e\xc1\x07\x3f\x6a\xc6\x13\x7b\xa2\x4b\xcc\xd2\x39\xe9\xc1\x99\x87\x57\x60\xcf\xae\x6d\xf5\x09\x2d\xb4\x56\x07\xb0\xd2\x2d\xf1\x25\x17\x87\xfa\x0c\xb2\x0e\x54\x14\xd9\x86\x1b\x0c\xd0\x88\x02\x4d\x83\x3b\x2a\xec\x23\x62\xde\xb7\xde\x41\xba\xa4\x1b\xd9\x03\xdc\x67\x8d\x1e\x4a\x74\xb7\x22\x86\x94\xc4\x40\xf3\xf0\x08\x95\x00\x10\x68\x65\x86\x5f\x6b\x01\x82\x27\xcd\xcd\x8a\xe7\x39\x70\xa6\x47\x37\xfa\x84\xc2\x56\x22\x6c\x36\xba\x25\x27\x72\xbb\x7f\xee\xf5\x8f\xb2\xd7\xe0\xd4\x85\xd9\xe5\xe3\xed\xb6\xc2\xff\x73\xbf\x7f\x94\xfd\x8e\x78\xc2\x0b\x6b\xed\x6e\xef\xa5\x1a\x7b\xf7\xbd\x1c\xe3\xf9\x16\xfb\xfd\x1d\xbc\x06\x8a\x7b\x85\x58\x2c\x12\xfe\x36\xce\xb8\x9c\x64\x94\x7f\xe8\x72\x1b\x3c\x2c\xc7\x82\xe7\xfd\x2b\x45\x5d\x0f\xf8\xee\xae\xe1\x7f\x33\x0e\xf2\x64\x3f\x1e\x8e\xeb\xbd\xe1\x48\x1b\xf9\xde\x8a\x3e\xd9\x2e\x73\x6a\x04\x8e\xdf\x87\xba\xef\x14\x59\x8a\xeb\x77\x18\x9e\xbd\xc7\x50\x71\xdc\x55\x77\x9d\x5f\x92\x1a\xdc\x0b\x64\x84\xe9\x4a\x34\x3d\xae\x53\x0e\xf2\x38\x2b\xfe\xa9\xa8\xa5\x5c\x2c\x70\xed\x4f\xa0\xcb\x75\xb0\x33\x13\x02\x88\x5f\xc6\x49\x94\xf5\x07\xed\x38\xdb\x27\x88\x73\x0b\x6e\x47\x75\xa9\xb9\x49\x0c\x9d\xa5\xa6\xa6\x9b\x24\xb9\x23\xc5\x1a\x2e\x93\x24\xbc\x91\x58\x4f\x31\x3e\x21\xcd\x07\x7a\x63\x33\x1d\x2d\x2b\xd8\x32\xbe\x33\x2c\x89\x22\xcf\xf1\x88\x80\x70\x16\x40\x94\x43\xc9\xe1\x0c\x37\x3a\x05\x6a\x5d\x6f\x30\xed\x83\xfb\x96\x35\x38\xd6\xbb\x71\x2d\x3d\x82\x00\x5d\xe6\x0e\x6c\x1c\x4e\x04\xc4\x6d\xac\x3c\xce\xa6\x7c\x4a\x2c\x26\x0f\x95\x9a\xba\xe5\xc0\xde\x38\xb8\x02\xa0\x18\x50\x0a\x4f\x5b\xea\x71\x62\x9f\xa0\xa3\x12\x2c\x53\x4d\x14\xef\xad\x72\x24\xc8\x15\x25\x21\xb6\x72\x67\xca\x53\x8b\xae\x01\xde\x7f\x5e\x83\x0d\xb3\x62\x3e\x6a\x4d\x9d\x4d\x92\x3a\x24\xe7\x02\xe4\x4f\x9e\x3c\x91\xf1\x00\x7c\x7d\xb3\x0c\x33\xd9\x20\x79\x8b\x40\x32\x49\xf5\x71\xee\x3a\x5f\xbe\x38\x1e\x7b\xc5\x9e\xbf\x40\xec\x00\xf1\xa4\x84\xc7\x74\x20\x74\xca\x61\x95\x35\x33\x01\xa6\xba\x7f\x82\x9f\x25\x2d\x88\x5f\x65\xc6\x4a\x50\x6a\x87\x11\x4a\x27\xb7\x5e\x1e\xb2\x17\x64\x96\x83\x17\x9b\x2c\x1d\x14\x6f\xb5\x62\xb1\x76\x09\xdd\xc3\x90\x4d\xc9\xa5\x84\xfa\x43\xc4\xe9\x10\x45\x15\xf8\xe1\x21\x2a\x3e\x41\x4f\x8d\xb0\x66\xea\x98\xc9\x6e\xec\x89\x5f\x22\xe8\x31\x2a\x08\x98\x86\x2b\x6e\x05\x28\x15\x8b\x74\xf0\x4a\xc5\xd0\x38\xac\x4d\xab\x94\x55\x79\x22\x8c\x25\x01\x4b\xc5\xaa\xe4\xae\x2d\xe7\x34\xd5\x86\xcd\x93\x67\xc9\x89\x62\x8b\xc2\xd2\x54\x57\x85\xa7\x9b\xe7\x25\x8f\xa0\x65\x1c\xf1\xe1\x55\xa9\x23\xe3\x7b\x2c\x4b\x99\xda\xed\xd7\x45\x9a\xe8\xe1\x85\xe9\x55\xa9\x93\xc5\xd2\x55\x14\x59\xbc\x88\xd3\x30\x01\xe7\x55\x36\xbc\xe5\xf3\x10\x5c\x2c\xb7\xe7\xe4\xa2\x4c\x65\x17\x12\x9c\xb5\x9a\x67\xcd\xa3\x96\x50\x32\x84\x7d\xf6\x0c\x87\xd4\x26\xe8\x38\xbd\x0a\x93\x38\x62\x9b\x9c\x67\x2c\x4c\x23\x36\x65\x02\xed\x56\x9e\x5f\x8b\x2c\x02\x4d\xdc\x39\x04\xfb\x84\xc3\x66\x70\x74\x5c\xf2\x94\x3e\x0f\x65\x17\x1a\xa5\xc1\x2b\x8b\xd6\xb9\xd9\xeb\x63\xfc\x1e\x47\x11\x4f\x81\xb5\xb3\xcb\x51\xce\x6e\xef\xc5\x84\x51\x74\x2c\xb3\x4f\xd6\xfe\x4b\x5b\xc8\x54\xea\x0a\x78\x4c\x3b\x33\xda\xf6\xa9\x29\xea\x9d\x69\x25\xbc\x88\xad\xd9\xa9\xc6\xd8\xdb\xe2\x56\xbe\x36\xbb\x21\xc6\xc9\x43\x8a\x2f\xf8\xb7\xf2\xfe\x8f\x2f\x8e\xbe\xad\xdd\x7a\x2e\xd3\x6f\x9e\x85\xc5\x6c\x09\x82\x32\x90\xf6\x1e\x60\x47\x25\x4c\x9f\x70\xce\x1d\x2a\x36\xe9\x39\x55\x3a\x2c\x54\x68\x1b\x77\x35\xe4\xb4\x1d\x4e\xaa\x51\xea\x62\xac\x6f\x95\x16\x29\x48\x23\x09\x20\xae\xb8\xa5\xc8\x20\xe9\xb9\xe9\x8b\x35\x6e\xaa\xda\x94\xe4\xe5\xe1\x49\x5f\x77\xe5\xea\xd4\xc4\x71\x83\xd7\x5b\xad\x9b\x53\x85\xab\xa4\xb2\xca\xc3\xea\x78\xb9\x49\xaf\x14\x3b\xa2\x5d\x8a\xd3\x32\xce\x83\x38\x22\x14\x5a\xf6\xe0\x41\xd9\xd7\x37\x10\x55\xc9\x7e\xb0\x00\x73\x58\x1e\x18\xd0\x5c\x24\x1b\x0b\xc0\xb7\x9b\x2c\x44\xb0\x53\x0e\x2d\x51\xde\x03\xad\x6e\xb6\xa1\x13\x0f\x65\xa2\x3f\x2e\x15\xf2\xdc\x71\xa7\x5f\xbe\x04\xff\xf9\x37\x7c\xfc\xf9\xe2\x36\xd8\x7d\xea\x39\x17\xc4\x80\xea\x8c\xec\x5b\x68\x7d\xda\xf4\x41\x28\x73\xdc\x0e\x42\xbc\xbe\xa9\xde\x8b\x34\x2e\x44\xd6\xb3\x3c\x3d\x99\x48\x92\x38\x5d\x0c\x73\x62\x05\xb6\x1b\xdc\xa3\x74\xc1\xff\x8e\xc9\x84\x51\xa8\x13\x9e\xcd\x14\x99\xc3\x70\xff\x92\xb7\x8a\xe4\xdc\x64\xc0\x5d\xcb\x50\x99\xcc\x8e\xa3\x7e\x59\x2a\x61\xf0\xeb\x88\x54\x95\xa0\x55\x9b\xad\x94\x95\x03\xbb\x9d\x5b\x89\x9f\x89\xc6\x80\x18\x12\xce\x72\xa8\x6a\x18\x12\xd3\x75\xcb\xd0\x8d\xc8\xe7\xda\xf4\xeb\xc6\xc4\x75\xdd\xf1\x98\x2c\xc5\x77\xdd\x8a\x9a\x2d\x84\xb9\x45\x9a\x6e\xb6\x97\x6f\x83\xce\x56\xb7\x95\xe8\x97\x08\x8c\x1e\x4b\x85\xe8\x8e\xd6\x7d\x5b\x29\x4a\x17\x4b\x0b\xa2\xe9\x09\x55\x7f\x4d\xa7\x2b\x5e\x2c\x45\x94\x4f\x5a\x93\xe8\xeb\x20\x2c\xf1\xe9\x73\xa6\x47\x84\xf7\xc3\x66\xf5\x95\x67\xee\x10\x94\x67\xab\x4f\x14\xae\x1a\xc0\x42\x38\x9a\x08\x9a\x5d\x9e\xbd\x84\x34\x51\x50\x20\x9e\x95\x98\x34\xb1\x18\xbd\x9e\xa5\xac\xd0\x28\x74\xbf\xb7\x95\xc0\xd0\xa8\x5a\x50\xa6\x4f\x23\xdd\xaa\xfe\x2b\x7d\x7d\x9d\x7f\xf2\xf1\xf4\x8c\xb8\x83\x1f\xbf\xcb\x1f\xbf\xc7\x27\xef\xf0\x71\x05\xc6\xd5\x7d\x2b\x7b\x7c\xd0\x95\xee\x9f\xa2\xfd\x53\xb4\xef\x24\xda\x9f\x7f\x64\xc9\x56\x37\x7d\x43\xb2\xfd\xa0\x1a\xac\xa6\x7b\xc0\xe5\xd2\x17\x45\x4e\x1c\x41\x44\xa4\xfd\x3c\xe2\x9e\xc8\x9e\x3f\xea\xf6\xec\xff\x51\xf7\x2d\xf7\xed\xed\xd1\xbb\xa3\xb3\xa3\x1f\xd9\xf6\xf2\x42\x15\xd1\x0e\x6d\x82\x4d\xc9\xd8\x6f\x47\x67\x76\x25\x63\xb9\x9c\xee\x11\x2a\xc7\x68\xf2\xc6\x05\x96\x12\xda\x87\xa8\x34\x6b\x14\x06\x35\x12\x1a\x65\x4b\xdf\x8d\x3d\x86\xe7\x25\x4c\x1f\x66\x19\xea\xc3\x71\xe3\xca\x94\x89\x8c\xc9\xe0\xd7\xcb\x6a\x36\x9d\xe3\x87\xc6\xdd\xdd\x21\x1c\xe6\x7c\xe7\xf1\x85\x8c\xdc\x0e\x4b\x5e\x8d\x0d\x6e\x94\x2c\xc9\xc1\x79\x29\x4a\x8d\x0a\x3a\xdd\x78\x70\x07\x44\x6f\xc4\x46\x1e\x44\x4d\x6c\x33\x6c\x1b\x47\x56\xde\x99\x97\x1c\x71\xd8\x2e\x8b\xfd\xe6\x1c\x9e\x05\x12\x22\x6b\xd5\x91\x92\xc9\x76\x3d\xdd\xd6\xdb\xe0\x2b\x9c\x83\x4a\x71\xbb\x9a\x7a\x6b\x64\x76\x74\x40\xa6\x03\x31\x33\xaf\xd3\x6d\xae\x34\x1d\xd4\xb0\x2f\x61\x21\x93\xc0\x3d\x7d\x65\x4a\xb8\xaf\x9f\xca\x00\xf7\xe7\x05\x14\x11\x8a\x4a\xfc\xd2\x4f\x4c\x09\x03\xdf\x86\xa9\xd2\x80\x65\xcb\x00\x89\x1a\x52\x7e\x3d\x20\x82\x2f\x09\x0c\x4a\x7a\xa6\xe1\x2b\x3d\xc7\xe4\xc4\xa3\x1c\xdc\xca\xbd\x9b\x4a\x8a\x1e\xfd\xd8\xc6\x55\xec\xcb\x4f\xda\xa8\x2a\xf6\x28\x3b\x79\xaf\x53\x5d\x27\xfb\xcd\x13\x85\x78\x62\x51\xef\xce\xb3\x67\x6c\xa7\xbc\x01\xba\x8c\xd7\x72\x0b\x1c\xf2\x0e\xba\xb5\x4b\x3d\x97\xfb\xf2\x46\xcf\xf3\xc8\x65\x6e\x65\xc3\x3b\x32\xd4\x34\x45\x86\x20\x91\xe3\x06\xd4\x87\xf2\x4a\xa8\xe2\x02\xd2\x4e\xd0\x4b\xdb\xa6\xdc\xa7\xb7\x54\x63\xbb\xa2\x2a\xea\x71\x88\xe5\x3d\xf2\x83\x54\xa2\x6b\x15\xc2\x3b\xa0\xef\xea\x53\x34\xb5\xc5\xff\x6e\x3e\x45\xe3\x36\x9a\x7c\xf9\xd3\xd6\xf5\x86\xb0\xfa\xbd\xa0\x78\x55\x61\x09\x8a\xf7\xdf\xfb\x32\xd3\xee\xdf\x8b\x2f\xc6\xcd\x87\x79\xa9\xd7\xa4\xc5\xbc\xdd\xf3\x25\xe2\xe1\x42\xb9\x6e\xa5\x10\x71\x63\xbf\x6d\xb5\xd2\xb8\x06\xde\xa5\x16\xf2\x3e\x9a\x37\xea\x37\xb4\x1d\x87\x76\xa5\x61\x83\xac\xb1\x23\x8c\x56\xc1\xfb\x06\x30\x86\x26\x58\x6b\x81\xe1\xd8\xd5\x5c\x93\xaf\x35\xba\x5c\x92\x75\x2f\x0d\x88\x9e\xfd\xa0\xdc\xe9\xa6\xad\xb7\xf4\xa8\xa5\xcf\xaf\x53\xe7\xc4\x6b\x92\x61\xb7\x73\x5d\x47\x63\xbd\x67\xc0\x64\xb8\xc5\xe4\xaa\xad\x48\x0e\xd4\x7e\x96\xc6\xfd\x96\x90\x27\xf9\xbe\xc9\x7c\x28\x79\x2f\x79\x4a\xc4\xe2\xd1\x1d\x21\xf5\x40\xd5\x7c\xf6\xe5\x0f\x1c\x43\xf4\x53\xca\xae\x4f\x74\x37\x91\x46\xa1\x01\x32\x8c\xb8\x89\x30\x92\xe4\x6b\x35\xf9\x4a\x11\xfd\x27\x44\x51\x56\x77\xbd\xa2\x9f\xb0\xa9\x2b\x86\xd9\x92\xcf\x2e\x11\xd9\x3c\xce\xf2\x02\xc7\xe1\xb3\x65\x10\x59\x70\xbc\x8b\x65\x08\x5b\x1b\xea\x66\x7c\x1f\xd8\x1b\xdb\x22\xd4\x3b\x00\x68\xbe\x4d\xa4\x9e\x20\x2e\xc6\x9f\x20\xf6\xbd\xb2\xaa\xa7\x59\x34\x67\xc1\x95\x9e\xef\x5d\x04\xd7\x4b\x9e\xf6\x1d\x26\xc8\xab\x72\xe8\xab\x8a\x56\x0b\x67\x08\x66\x91\xf6\x38\x1f\x3e\x37\x7a\x8b\x8d\x07\xd0\x0d\xd5\xbb\x53\x9a\x3d\xb1\xb1\x52\x8d\x6d\x1f\x34\x4f\x92\x69\xb1\x62\x5a\x87\x99\xba\xdd\x03\x9f\x56\x56\xa3\xf1\x53\xa9\x43\xe4\x2b\x80\xc9\xc0\xcb\x48\x5c\x25\xfe\x32\x77\xe5\x71\xed\x52\xdb\x30\x95\x6e\x44\x14\x67\xaa\x16\x4f\xd7\x63\xf8\xac\x1b\x1d\x23\x2f\x75\x60\x3c\x95\x42\x3b\x6d\x38\xe9\xb2\x16\x98\x0c\xa8\xcb\x21\xda\xf9\x6c\x86\xbe\x93\x76\x40\xae\x89\xb0\xac\x55\xed\xd4\x3e\x3f\x80\x8b\x8c\xb4\xee\x2a\x37\x6a\xfa\x30\xae\xaf\x5e\xd3\x7e\xf9\xc7\xb8\x33\xa8\x7e\xff\x74\x93\xb7\xe5\xcc\x81\xbd\x9f\x6c\x0a\xf9\xff\xf4\x7d\x08\x15\x00\x36\x34\xe3\x1e\x1e\x02\x7c\x3c\xb2\x77\x60\x27\xdd\x63\xfb\xf7\x90\xbe\x41\x99\x41\x3e\xbf\x38\xb0\x3b\x12\xb6\x74\x5c\xe5\x1c\xb2\x84\x2a\x5e\x9d\xc2\x49\xb9\xec\xba\xac\x58\x83\xde\xad\x6c\x57\xb5\xed\xf3\x81\xc2\x76\xba\xb4\xdd\x28\x6e\x27\xcb\xdb\xb1\xc0\xbd\x8f\x58\x5d\x3b\x34\x37\x2b\xde\xa9\xa2\x74\x22\x3d\xda\x4a\x36\x55\x85\xa7\x74\xb6\xa9\x55\xac\x0e\xd3\x82\xdb\x81\xf9\x71\x2c\xce\x1b\xc9\x8d\x63\x05\x6f\x9c\x9a\x45\x69\x36\x09\x62\x3a\x40\x91\x69\x6c\x59\xe1\x38\x66\xcf\xfa\x53\x7c\xa4\x21\x83\x1d\xbe\xbf\x9d\x9a\x8c\x2f\x4e\x19\xe5\xca\x4e\xcd\x89\xab\xe8\xbb\xba\x09\x06\xea\x01\xfb\xb5\x3f\x14\x64\xfb\xa3\x46\xad\x5a\xaa\x19\x0d\xd5\xba\x63\xbc\x1d\x42\xc1\x81\xa6\x00\x3d\xd0\xe3\x52\x4f\xa6\xda\x6f\x97\x1d\xca\x63\x7f\xde\x7d\x8e\xa1\x53\xcc\x08\x94\xcb\xca\xd0\x3d\xdf\x1c\x41\xa5\xb1\xeb\x61\x74\xe0\xd6\xf9\xdf\x39\x86\x3f\x64\x77\x67\x35\x74\x4f\x75\x9f\xbb\x29\x2a\x82\x1e\xbe\xb5\x19\x08\x9b\x6f\x09\xf6\xe8\x10\x05\xc6\x9c\xc5\x2b\x2e\x36\x85\x6b\x30\xc4\x67\x7f\xd9\xdb\xdb\xeb\xf3\x2e\xd5\x29\x08\x9f\xe6\x26\xe3\xd9\x25\xe6\xaa\x30\x1f\x2d\x84\x3a\x67\x9c\xae\xe7\x07\x51\x17\x77\xeb\xff\x4c\xe4\x57\xff\x4a\x08\xfd\x3f\x1f\x11\x8c\x9d\xa4\x54\xe8\xa1\x1e\x04\x60\x8d\x7f\x53\xb2\xc9\xc7\x00\xc1\x32\xcc\x3f\x5e\xa7\xc0\xdd\x35\x10\x70\x03\x81\x19\xa8\xd9\x26\xc3\xfd\xa5\x76\x84\xda\x4d\xc4\x52\x8e\xb2\x39\x62\x6c\x56\x5d\x21\xc4\x68\x64\x20\xed\x65\xf7\xe6\xd3\x6a\x46\x7a\x16\x53\x6a\x40\x06\x50\xef\xff\x0b\xd5\x0d\x12\x49\x2a\x4c\x00\x00")
 
 func webJsIndexJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -276,7 +276,7 @@ func webJsIndexJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/js/index.js", size: 17490, mode: os.FileMode(436), modTime: time.Unix(1452700143, 0)}
+	info := bindataFileInfo{name: "web/js/index.js", size: 19498, mode: os.FileMode(436), modTime: time.Unix(1792392559, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
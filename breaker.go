@@ -0,0 +1,98 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// The circuit breaker pauses a profile when most of its recent changes are failing, so
+// a broken connection or revoked access doesn't churn through every queued change
+const (
+	breakerWindow    = time.Minute // period failures are counted over
+	breakerMinErrors = 10          // failures needed within the window before the breaker can trip
+	breakerErrorRate = 0.5         // fraction of changes in the window that must fail to trip the breaker
+	breakerCooldown  = 5 * time.Minute
+
+	pauseTooManyErrors = "too many errors"
+)
+
+type breakerState struct {
+	start     time.Time // start of the current window
+	failures  int
+	successes int
+	tripped   bool
+}
+
+type circuitBreaker struct {
+	sync.Mutex
+	profiles map[string]*breakerState
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{
+		profiles: make(map[string]*breakerState),
+	}
+}
+
+// state returns the profile's breaker state for the current window, must be called under lock
+func (b *circuitBreaker) state(profileID string) *breakerState {
+	s, ok := b.profiles[profileID]
+	if !ok {
+		s = &breakerState{start: time.Now()}
+		b.profiles[profileID] = s
+	}
+	if !s.tripped && time.Since(s.start) > breakerWindow {
+		s.start = time.Now()
+		s.failures = 0
+		s.successes = 0
+	}
+	return s
+}
+
+func (b *circuitBreaker) success(profileID string) {
+	b.Lock()
+	defer b.Unlock()
+	b.state(profileID).successes++
+}
+
+// failure counts a failed change, and pauses the profile if its error rate is too high
+func (b *circuitBreaker) failure(profileID string) {
+	b.Lock()
+	defer b.Unlock()
+	s := b.state(profileID)
+	s.failures++
+
+	if s.tripped || s.failures < breakerMinErrors {
+		return
+	}
+	if float64(s.failures)/float64(s.failures+s.successes) < breakerErrorRate {
+		return
+	}
+
+	s.tripped = true
+	syncer.PauseFor(profileID, pauseTooManyErrors)
+	log.Warn(fmt.Sprintf("%d of the last %d changes for profile %s have failed.  The profile will be paused for %s.",
+		s.failures, s.failures+s.successes, profileID, breakerCooldown), "Both", log.Fields{Profile: profileID})
+
+	time.AfterFunc(breakerCooldown, func() {
+		b.reset(profileID)
+	})
+}
+
+// reset resumes the profile after its cooldown, and starts counting from scratch
+func (b *circuitBreaker) reset(profileID string) {
+	b.Lock()
+	defer b.Unlock()
+	delete(b.profiles, profileID)
+	syncer.ResumeFrom(profileID, pauseTooManyErrors)
+	log.Info(fmt.Sprintf("Resuming profile %s after pausing for too many errors.", profileID), "Both",
+		log.Fields{Profile: profileID})
+}
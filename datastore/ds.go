@@ -7,8 +7,10 @@
 package datastore
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/boltdb/bolt"
@@ -18,15 +20,21 @@ var ds *bolt.DB
 
 // Supported Buckets
 const (
-	BucketProfile = "profiles"
-	BucketLog     = "log"
-	BucketRemote  = "remote"
+	BucketProfile     = "profiles"
+	BucketLog         = "log"
+	BucketRemote      = "remote"
+	BucketMeta        = "meta"
+	BucketJournal     = "journal"
+	BucketFailure     = "failure"
+	BucketActivity    = "activity"
+	BucketStats       = "stats"
+	BucketFingerprint = "fingerprint"
 )
 
 // ErrNotFound is returned when a value isn't found for the passed in key
 var ErrNotFound = errors.New("Value not found")
 
-// Open opens a the bolt datastore
+// Open opens a the bolt datastore, and migrates it to the current schema version
 func Open(filename string) error {
 	db, err := bolt.Open(filename, 0666, &bolt.Options{Timeout: 1 * time.Minute})
 
@@ -34,22 +42,51 @@ func Open(filename string) error {
 		return err
 	}
 	ds = db
-	return ds.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists([]byte(BucketProfile))
-		if err != nil {
-			return err
-		}
-		_, err = tx.CreateBucketIfNotExists([]byte(BucketLog))
-		if err != nil {
-			return err
-		}
-		_, err = tx.CreateBucketIfNotExists([]byte(BucketRemote))
-		if err != nil {
-			return err
-		}
 
-		return nil
-	})
+	err = migrate(filename)
+	if err != nil {
+		ds.Close()
+		ds = nil
+		return fmt.Errorf("Error opening datastore %s: %s", filename, err)
+	}
+	return nil
+}
+
+func createBuckets(tx *bolt.Tx) error {
+	_, err := tx.CreateBucketIfNotExists([]byte(BucketProfile))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateBucketIfNotExists([]byte(BucketLog))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateBucketIfNotExists([]byte(BucketRemote))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateBucketIfNotExists([]byte(BucketJournal))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateBucketIfNotExists([]byte(BucketFailure))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateBucketIfNotExists([]byte(BucketActivity))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateBucketIfNotExists([]byte(BucketStats))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateBucketIfNotExists([]byte(BucketFingerprint))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CreateBucketIfNotExists([]byte(BucketMeta))
+	return err
 }
 
 // Close closes the bolt datastore
@@ -107,6 +144,14 @@ func Delete(bucket string, key interface{}) error {
 	})
 }
 
+// SequenceKey returns the key for the passed in bucket sequence number, which
+// sorts in the same order as the sequence
+func SequenceKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
+
 // DB returns the underlying bolt DB
 func DB() *bolt.DB {
 	return ds
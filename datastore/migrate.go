@@ -0,0 +1,246 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package datastore
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+const versionKey = "version"
+
+// migration upgrades the datastore layout by one schema version
+type migration struct {
+	description string
+	run         func(tx *bolt.Tx) error
+}
+
+// migrations are run in order at startup.  The migration at index i upgrades the
+// datastore from schema version i to i+1, so new migrations must only ever be appended
+var migrations = []migration{
+	{"Store remote folder views as a nested bucket per folder", migrateRemoteEntries},
+	{"Key log entries by sequence instead of time", migrateLogKeys},
+	{"Store the size and type of remote folder entries", migrateRemoteSizes},
+}
+
+// SchemaVersion is the current version of the datastore layout
+func SchemaVersion() int {
+	return len(migrations)
+}
+
+// migrate brings the open datastore up to the current schema version.  A copy of the
+// datastore file is made before any migrations are run, and all migrations are run in
+// a single transaction
+func migrate(filename string) error {
+	version := 0
+	fresh := false
+
+	err := ds.View(func(tx *bolt.Tx) error {
+		meta := tx.Bucket([]byte(BucketMeta))
+		if meta == nil {
+			// datastores from before versioning won't have a meta bucket
+			fresh = tx.Bucket([]byte(BucketProfile)) == nil
+			return nil
+		}
+		value := meta.Get([]byte(versionKey))
+		if value == nil {
+			return nil
+		}
+		return json.Unmarshal(value, &version)
+	})
+	if err != nil {
+		return err
+	}
+
+	if version > SchemaVersion() {
+		return fmt.Errorf("The datastore is at schema version %d, which is newer than the version %d "+
+			"this freehold-sync supports.  Please upgrade freehold-sync.", version, SchemaVersion())
+	}
+
+	if version < SchemaVersion() && !fresh {
+		backup := fmt.Sprintf("%s.v%d.bak", filename, version)
+		err = ds.View(func(tx *bolt.Tx) error {
+			return tx.CopyFile(backup, 0600)
+		})
+		if err != nil {
+			return fmt.Errorf("Error backing up datastore before migrating: %s", err)
+		}
+		fmt.Printf("Migrating datastore from schema version %d to %d. A backup was saved to %s\n",
+			version, SchemaVersion(), backup)
+	}
+
+	return ds.Update(func(tx *bolt.Tx) error {
+		err := createBuckets(tx)
+		if err != nil {
+			return err
+		}
+
+		for i := version; i < SchemaVersion(); i++ {
+			err = migrations[i].run(tx)
+			if err != nil {
+				return fmt.Errorf("Error migrating datastore to schema version %d (%s): %s",
+					i+1, migrations[i].description, err)
+			}
+		}
+
+		value, err := json.Marshal(SchemaVersion())
+		if err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(BucketMeta)).Put([]byte(versionKey), value)
+	})
+}
+
+// migrateRemoteEntries moves remote folder views stored as a single JSON array value
+// keyed by the JSON encoded folder ID into a nested bucket per folder with an entry per file
+func migrateRemoteEntries(tx *bolt.Tx) error {
+	b := tx.Bucket([]byte(BucketRemote))
+
+	var oldKeys [][]byte
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if v != nil {
+			oldKeys = append(oldKeys, append([]byte(nil), k...))
+		}
+	}
+
+	for i := range oldKeys {
+		var dirID string
+		err := json.Unmarshal(oldKeys[i], &dirID)
+		if err != nil {
+			return err
+		}
+
+		var entries []json.RawMessage
+		err = json.Unmarshal(b.Get(oldKeys[i]), &entries)
+		if err != nil {
+			return err
+		}
+
+		dir, err := b.CreateBucketIfNotExists([]byte(dirID))
+		if err != nil {
+			return err
+		}
+
+		for j := range entries {
+			entry := struct {
+				ID string `json:"fullUrl"`
+			}{}
+			err = json.Unmarshal(entries[j], &entry)
+			if err != nil {
+				return err
+			}
+			err = dir.Put([]byte(entry.ID), entries[j])
+			if err != nil {
+				return err
+			}
+		}
+
+		err = b.Delete(oldKeys[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateLogKeys re-keys log entries, which were keyed by the JSON encoded time and
+// type of the entry, by the log bucket's sequence in the same order
+func migrateLogKeys(tx *bolt.Tx) error {
+	var entries [][]byte
+	c := tx.Bucket([]byte(BucketLog)).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		entries = append(entries, append([]byte(nil), v...))
+	}
+
+	err := tx.DeleteBucket([]byte(BucketLog))
+	if err != nil {
+		return err
+	}
+	b, err := tx.CreateBucket([]byte(BucketLog))
+	if err != nil {
+		return err
+	}
+
+	for i := range entries {
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		err = b.Put(SequenceKey(seq), entries[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateRemoteSizes fills in the size and type of remote folder entries stored before they
+// were recorded.  The size can't be known until the folder is listed again, so the entry's
+// modified time is cleared to mark it stale, and the next poll of the folder rewrites it
+func migrateRemoteSizes(tx *bolt.Tx) error {
+	var dirs [][]byte
+	c := tx.Bucket([]byte(BucketRemote)).Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if v == nil {
+			dirs = append(dirs, append([]byte(nil), k...))
+		}
+	}
+
+	for i := range dirs {
+		b := tx.Bucket([]byte(BucketRemote)).Bucket(dirs[i])
+		stale := make(map[string][]byte)
+		err := b.ForEach(func(k, v []byte) error {
+			entry := make(map[string]json.RawMessage)
+			err := json.Unmarshal(v, &entry)
+			if err != nil {
+				return err
+			}
+			if _, ok := entry["size"]; ok {
+				return nil
+			}
+
+			var id string
+			err = json.Unmarshal(entry["fullUrl"], &id)
+			if err != nil {
+				return err
+			}
+			isDir, err := json.Marshal(strings.HasSuffix(id, "/"))
+			if err != nil {
+				return err
+			}
+			modified, err := json.Marshal(time.Time{})
+			if err != nil {
+				return err
+			}
+
+			entry["size"] = json.RawMessage("0")
+			entry["isDir"] = isDir
+			entry["modified"] = modified
+			value, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			stale[string(k)] = value
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for k, v := range stale {
+			err = b.Put([]byte(k), v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package datastore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+func tempDS(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "freehold-sync-ds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "sync.ds"), func() { os.RemoveAll(dir) }
+}
+
+func TestMigrateOldLayout(t *testing.T) {
+	filename, cleanup := tempDS(t)
+	defer cleanup()
+
+	// build a datastore as it looked before schema versioning
+	db, err := bolt.Open(filename, 0666, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dirKey, _ := json.Marshal("/v1/file/docs/")
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucket([]byte(BucketProfile))
+		if err != nil {
+			return err
+		}
+		b, err := tx.CreateBucket([]byte(BucketRemote))
+		if err != nil {
+			return err
+		}
+		err = b.Put(dirKey, []byte(`[{"fullUrl":"/v1/file/docs/a.txt"},{"fullUrl":"/v1/file/docs/b/"}]`))
+		if err != nil {
+			return err
+		}
+
+		l, err := tx.CreateBucket([]byte(BucketLog))
+		if err != nil {
+			return err
+		}
+		err = l.Put([]byte(`"2015-06-01T10:00:01Z_remote"`), []byte(`{"log":"second"}`))
+		if err != nil {
+			return err
+		}
+		return l.Put([]byte(`"2015-06-01T10:00:00Z_local"`), []byte(`{"log":"first"}`))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	err = Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+
+	if _, err = os.Stat(filename + ".v0.bak"); err != nil {
+		t.Fatalf("Backup wasn't made before migrating: %s", err)
+	}
+
+	err = DB().View(func(tx *bolt.Tx) error {
+		var version int
+		err := json.Unmarshal(tx.Bucket([]byte(BucketMeta)).Get([]byte(versionKey)), &version)
+		if err != nil {
+			return err
+		}
+		if version != SchemaVersion() {
+			t.Fatalf("Schema version is %d, expected %d", version, SchemaVersion())
+		}
+
+		b := tx.Bucket([]byte(BucketRemote))
+		if b.Get(dirKey) != nil {
+			t.Fatal("Old remote entry wasn't removed")
+		}
+		dir := b.Bucket([]byte("/v1/file/docs/"))
+		if dir == nil {
+			t.Fatal("Remote folder wasn't migrated to a nested bucket")
+		}
+		if dir.Get([]byte("/v1/file/docs/a.txt")) == nil || dir.Get([]byte("/v1/file/docs/b/")) == nil {
+			t.Fatal("Remote folder entries weren't migrated")
+		}
+
+		l := tx.Bucket([]byte(BucketLog))
+		if string(l.Get(SequenceKey(1))) != `{"log":"first"}` || string(l.Get(SequenceKey(2))) != `{"log":"second"}` {
+			t.Fatal("Log entries weren't re-keyed in order")
+		}
+		if l.Sequence() != 2 {
+			t.Fatalf("Log sequence is %d, expected 2", l.Sequence())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewDatastoreNoBackup(t *testing.T) {
+	filename, cleanup := tempDS(t)
+	defer cleanup()
+
+	err := Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Close()
+
+	if _, err = os.Stat(filename + ".v0.bak"); !os.IsNotExist(err) {
+		t.Fatal("Backup was made of a new datastore")
+	}
+
+	// reopening a current datastore should be a no-op
+	err = Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Close()
+}
+
+func TestRefuseNewerVersion(t *testing.T) {
+	filename, cleanup := tempDS(t)
+	defer cleanup()
+
+	err := Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = DB().Update(func(tx *bolt.Tx) error {
+		value, _ := json.Marshal(SchemaVersion() + 1)
+		return tx.Bucket([]byte(BucketMeta)).Put([]byte(versionKey), value)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	Close()
+
+	if Open(filename) == nil {
+		Close()
+		t.Fatal("Datastore with a newer schema version was opened")
+	}
+}
+
+func TestMigrateRemoteSizes(t *testing.T) {
+	filename, cleanup := tempDS(t)
+	defer cleanup()
+
+	// build a datastore at the version before remote entries stored their size
+	err := Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := []byte(`{"fullUrl":"/v1/file/docs/c.txt","modified":"2015-06-01T10:00:00Z","size":12,"isDir":false}`)
+	err = DB().Update(func(tx *bolt.Tx) error {
+		value, _ := json.Marshal(2)
+		err := tx.Bucket([]byte(BucketMeta)).Put([]byte(versionKey), value)
+		if err != nil {
+			return err
+		}
+		dir, err := tx.Bucket([]byte(BucketRemote)).CreateBucket([]byte("/v1/file/docs/"))
+		if err != nil {
+			return err
+		}
+		err = dir.Put([]byte("/v1/file/docs/a.txt"),
+			[]byte(`{"fullUrl":"/v1/file/docs/a.txt","modified":"2015-06-01T10:00:00Z"}`))
+		if err != nil {
+			return err
+		}
+		err = dir.Put([]byte("/v1/file/docs/b/"),
+			[]byte(`{"fullUrl":"/v1/file/docs/b/","modified":"2015-06-01T10:00:00Z"}`))
+		if err != nil {
+			return err
+		}
+		return dir.Put([]byte("/v1/file/docs/c.txt"), current)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	Close()
+
+	err = Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Close()
+
+	if _, err = os.Stat(filename + ".v2.bak"); err != nil {
+		t.Fatalf("Backup wasn't made before migrating: %s", err)
+	}
+
+	tests := []struct {
+		id       string
+		size     int64
+		isDir    bool
+		modified time.Time
+	}{
+		{"/v1/file/docs/a.txt", 0, false, time.Time{}},
+		{"/v1/file/docs/b/", 0, true, time.Time{}},
+		{"/v1/file/docs/c.txt", 12, false, time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC)},
+	}
+
+	err = DB().View(func(tx *bolt.Tx) error {
+		dir := tx.Bucket([]byte(BucketRemote)).Bucket([]byte("/v1/file/docs/"))
+		for _, test := range tests {
+			entry := struct {
+				Modified time.Time `json:"modified"`
+				Size     *int64    `json:"size"`
+				IsDir    bool      `json:"isDir"`
+			}{}
+			err := json.Unmarshal(dir.Get([]byte(test.id)), &entry)
+			if err != nil {
+				return err
+			}
+			if entry.Size == nil || *entry.Size != test.size {
+				t.Errorf("%s size is %v, expected %d", test.id, entry.Size, test.size)
+			}
+			if entry.IsDir != test.isDir {
+				t.Errorf("%s isDir is %t, expected %t", test.id, entry.IsDir, test.isDir)
+			}
+			if !entry.Modified.Equal(test.modified) {
+				t.Errorf("%s modified is %s, expected %s", test.id, entry.Modified, test.modified)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
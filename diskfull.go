@@ -0,0 +1,145 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/remote"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// A profile is paused when a change fails because the disk it's writing to is full, and
+// resumed once there is space again.  Freehold doesn't report how much space an instance
+// has left, so a profile paused for a full remote instance is resumed after a wait, and
+// paused again if the instance is still full
+const (
+	pauseDiskFull      = "disk full"
+	diskFullCheck      = 30 * time.Second // how often a full local disk is checked for space
+	diskFullRemoteWait = 5 * time.Minute  // wait before retrying writes to a full remote instance
+)
+
+var diskFull diskFullMonitor
+
+func init() {
+	diskFull = diskFullMonitor{
+		profiles: make(map[string]*fullDisk),
+	}
+}
+
+// fullDisk is a profile paused because of a full disk, and the changes waiting on space
+type fullDisk struct {
+	local   bool  // local disk is full
+	remote  bool  // remote instance is full
+	needed  int64 // most bytes needed by a held change on the local disk
+	since   time.Time
+	entries []*journalEntry
+}
+
+type diskFullMonitor struct {
+	sync.Mutex
+	profiles map[string]*fullDisk
+}
+
+// holdIfDiskFull holds the change and pauses the profile if the error was caused by
+// running out of space.  Held changes don't count as failures
+func holdIfDiskFull(p *syncer.Profile, entry *journalEntry, err error) bool {
+	var isLocal bool
+	var needed int64
+
+	switch e := unwrapError(err).(type) {
+	case *local.SpaceError:
+		isLocal = true
+		needed = e.Needed
+	case syscall.Errno:
+		if e != syscall.ENOSPC {
+			return false
+		}
+		isLocal = true
+	case *remote.StatusError:
+		if e.StatusCode != http.StatusInsufficientStorage {
+			return false
+		}
+	default:
+		return false
+	}
+
+	saveErr := entry.save()
+	if saveErr != nil {
+		log.Error(fmt.Sprintf("Error journaling change to %s: %s", entry.Local, saveErr), entry.LogType)
+	}
+	diskFull.hold(p, entry, isLocal, needed, err)
+	return true
+}
+
+// hold adds the change to the profile's held changes, pausing the profile if it isn't already
+func (d *diskFullMonitor) hold(p *syncer.Profile, entry *journalEntry, isLocal bool, needed int64, err error) {
+	d.Lock()
+	defer d.Unlock()
+
+	f, ok := d.profiles[p.ID()]
+	if !ok {
+		f = &fullDisk{since: time.Now()}
+		d.profiles[p.ID()] = f
+		syncer.PauseFor(p.ID(), pauseDiskFull)
+		log.Warn(fmt.Sprintf("Pausing profile %s until there is enough free space.  Error: %s", p.ID(), err),
+			entry.LogType, log.Fields{
+				Profile: p.ID(),
+				Path:    entry.path(p),
+			})
+		go d.watch(p)
+	}
+
+	if isLocal {
+		f.local = true
+		if needed > f.needed {
+			f.needed = needed
+		}
+	} else {
+		f.remote = true
+	}
+	f.entries = append(f.entries, entry)
+}
+
+// watch checks for space until the profile can be resumed
+func (d *diskFullMonitor) watch(p *syncer.Profile) {
+	for {
+		time.Sleep(diskFullCheck)
+		if d.resume(p) {
+			return
+		}
+	}
+}
+
+// resume resumes the profile and syncs its held changes if there is space for them
+func (d *diskFullMonitor) resume(p *syncer.Profile) bool {
+	d.Lock()
+	defer d.Unlock()
+
+	f, ok := d.profiles[p.ID()]
+	if !ok {
+		return true
+	}
+	if f.local && !local.HasSpace(p.Local.ID(), f.needed) {
+		return false
+	}
+	if f.remote && time.Since(f.since) < diskFullRemoteWait {
+		return false
+	}
+
+	delete(d.profiles, p.ID())
+	syncer.ResumeFrom(p.ID(), pauseDiskFull)
+	log.Info(fmt.Sprintf("Resuming profile %s after pausing for a full disk.", p.ID()), "Both",
+		log.Fields{Profile: p.ID()})
+
+	go resyncEntries(p, f.entries)
+	return true
+}
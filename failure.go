@@ -0,0 +1,97 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// failureInput selects a single failure by ID, or all failures, optionally
+// limited to a single profile
+type failureInput struct {
+	ID      string `json:"id"`
+	All     bool   `json:"all"`
+	Profile string `json:"profile"`
+}
+
+// selectFailures returns the failures the input applies to
+func selectFailures(input *failureInput) ([]*failure, error) {
+	if input.All {
+		return allFailures(input.Profile)
+	}
+
+	if strings.TrimSpace(input.ID) == "" {
+		return nil, errors.New("No ID specified. You must specify a failure ID, or all failures.")
+	}
+
+	f, err := getFailure(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return []*failure{f}, nil
+}
+
+func failureGet(w http.ResponseWriter, r *http.Request) {
+	input := &failureInput{}
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	failures, err := allFailures(input.Profile)
+	if errHandled(err, w) {
+		return
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+		Data:   failures,
+	})
+}
+
+func failureRetryPost(w http.ResponseWriter, r *http.Request) {
+	input := &failureInput{}
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	failures, err := selectFailures(input)
+	if errHandled(err, w) {
+		return
+	}
+
+	for i := range failures {
+		if errHandled(failures[i].retry(), w) {
+			return
+		}
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+	})
+}
+
+func failureDismissPost(w http.ResponseWriter, r *http.Request) {
+	input := &failureInput{}
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	failures, err := selectFailures(input)
+	if errHandled(err, w) {
+		return
+	}
+
+	for i := range failures {
+		if errHandled(failures[i].dismiss(), w) {
+			return
+		}
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+	})
+}
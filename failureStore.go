@@ -0,0 +1,148 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+const bucketFailure = datastore.BucketFailure
+
+// failure is a change which is no longer being retried, and is waiting in the
+// failure inbox to be either retried manually or dismissed.  Failures are
+// keyed the same as the journal, so repeated failures of the same file
+// update a single record
+type failure struct {
+	ID string `json:"id"`
+	journalEntry
+	Change      string    `json:"change"` // type of change that failed: write, delete, rename, createDir or sync
+	Error       string    `json:"error"`
+	FirstFailed time.Time `json:"firstFailed"`
+	LastFailed  time.Time `json:"lastFailed"`
+}
+
+// changeType returns the type of change which caused the sync error
+func changeType(err error) string {
+	if ce, ok := err.(*syncer.ChangeError); ok {
+		return ce.Change
+	}
+	return "sync"
+}
+
+// recordFailure adds the journaled change to the failure inbox
+func recordFailure(entry *journalEntry, err error) error {
+	now := time.Now()
+	f := &failure{}
+	getErr := datastore.Get(bucketFailure, entry.key(), f)
+	if getErr == datastore.ErrNotFound {
+		f.FirstFailed = now
+	} else if getErr != nil {
+		return getErr
+	}
+
+	attempts := f.Attempts + entry.Attempts
+	f.ID = entry.key()
+	f.journalEntry = *entry
+	f.Attempts = attempts
+	f.Change = changeType(err)
+	f.Error = err.Error()
+	f.LastFailed = now
+
+	return datastore.Put(bucketFailure, f.ID, f)
+}
+
+// clearFailure removes any failure recorded for the journaled change
+func clearFailure(entry *journalEntry) error {
+	f := &failure{}
+	err := datastore.Get(bucketFailure, entry.key(), f)
+	if err == datastore.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return f.dismiss()
+}
+
+func getFailure(id string) (*failure, error) {
+	f := &failure{}
+	err := datastore.Get(bucketFailure, id, f)
+	if err == datastore.ErrNotFound {
+		return nil, errors.New("Failure not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// allFailures returns every failure in the inbox, or only the failures for the
+// passed in profile if profileID isn't empty
+func allFailures(profileID string) ([]*failure, error) {
+	all := []*failure{}
+	err := datastore.DB().View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketFailure)).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			f := &failure{}
+			err := json.Unmarshal(v, f)
+			if err != nil {
+				return err
+			}
+			if profileID == "" || f.ProfileID == profileID {
+				all = append(all, f)
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+func failureCount(profileID string) (int, error) {
+	all, err := allFailures(profileID)
+	if err != nil {
+		return 0, err
+	}
+	return len(all), nil
+}
+
+// retry removes the failure from the inbox and queues it back up for syncing
+func (f *failure) retry() error {
+	p := syncer.Running(f.ProfileID)
+	if p == nil {
+		return errors.New("The sync profile for this failure isn't running.")
+	}
+
+	l, r, err := f.syncers(p)
+	if err != nil {
+		return err
+	}
+
+	err = f.dismiss()
+	if err != nil {
+		return err
+	}
+
+	entry := f.journalEntry
+	entry.Attempts = 0
+	entry.Queued = time.Now()
+	go syncChange(p, &entry, l, r)
+	return nil
+}
+
+func (f *failure) dismiss() error {
+	return datastore.Delete(bucketFailure, f.ID)
+}
@@ -0,0 +1,104 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+func TestFailureInbox(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-failure")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+
+	entries := []*journalEntry{
+		{ProfileID: "one", Local: "/docs/a.txt", Remote: "/v1/file/docs/a.txt", Attempts: 3},
+		{ProfileID: "one", Local: "/docs/b.txt", Remote: "/v1/file/docs/b.txt", Attempts: 3},
+		{ProfileID: "two", Local: "/pics/c.jpg", Remote: "/v1/file/pics/c.jpg", Attempts: 3},
+	}
+	for i := range entries {
+		err = recordFailure(entries[i], &syncer.ChangeError{Change: "write", Err: errors.New("failed")})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// failing again updates the existing failure
+	first, err := getFailure(entries[0].key())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = recordFailure(entries[0], errors.New("failed again"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := getFailure(entries[0].key())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Attempts != 6 || again.Change != "sync" || again.Error != "failed again" ||
+		!again.FirstFailed.Equal(first.FirstFailed) {
+		t.Errorf("Repeated failure has %d attempts, change %s, error %q and first failed %s, expected 6 "+
+			"attempts, change sync, error \"failed again\" and first failed %s", again.Attempts, again.Change,
+			again.Error, again.FirstFailed, first.FirstFailed)
+	}
+
+	// retrying a failure of a profile which isn't running keeps it in the inbox
+	if again.retry() == nil {
+		t.Errorf("Retrying a failure of a profile which isn't running succeeded")
+	}
+
+	err = clearFailure(entries[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = clearFailure(entries[1])
+	if err != nil {
+		t.Errorf("Clearing a change without a failure returned %s", err)
+	}
+
+	tests := []struct {
+		profile  string
+		failures int
+	}{
+		{"", 2},
+		{"one", 1},
+		{"two", 1},
+		{"three", 0},
+	}
+
+	for _, test := range tests {
+		count, err := failureCount(test.profile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != test.failures {
+			t.Errorf("Profile %q has %d failures, expected %d", test.profile, count, test.failures)
+		}
+	}
+
+	err = again.dismiss()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = getFailure(again.ID); err == nil {
+		t.Errorf("Dismissed failure is still in the inbox")
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import "net/http"
+
+func heldGet(w http.ResponseWriter, r *http.Request) {
+	input := &profileControlInput{}
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+		Data:   batches.held(input.ID),
+	})
+}
+
+func heldConfirmPost(w http.ResponseWriter, r *http.Request) {
+	releaseHeld(w, r, true)
+}
+
+func heldRejectPost(w http.ResponseWriter, r *http.Request) {
+	releaseHeld(w, r, false)
+}
+
+func releaseHeld(w http.ResponseWriter, r *http.Request, confirmed bool) {
+	input := &profileControlInput{}
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	profiles, err := controlProfiles(input)
+	if errHandled(err, w) {
+		return
+	}
+
+	for i := range profiles {
+		if confirmed {
+			profiles[i].confirm()
+		} else {
+			profiles[i].reject()
+		}
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+	})
+}
@@ -0,0 +1,198 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// Only one freehold-sync can run against a data folder at a time.  The running instance
+// keeps a lock file in the data folder with its PID and web port, so a second instance
+// can say where the first one is instead of waiting on the datastore
+const lockFileName = "freehold-sync.lock"
+
+// lockWriteWait is how long an unreadable lock file is assumed to still be being written
+const lockWriteWait = 10 * time.Second
+
+var errAlreadyRunning = errors.New("Freehold-Sync is already running")
+
+// instance is this running instance, set once its lock is held
+var instance *instanceInfo
+
+type instanceInfo struct {
+	PID      int       `json:"pid"`
+	Port     string    `json:"port"`
+	Started  time.Time `json:"started"`
+	lockFile string
+}
+
+func (i *instanceInfo) url() string {
+	return "http://localhost:" + i.Port
+}
+
+// lockInstance creates the lock file for this instance.  If another instance holds the lock,
+// errAlreadyRunning is returned along with that instance, which is nil if it's still starting.
+// A lock left behind by an instance which is no longer running is replaced
+func lockInstance(filename, port string) (*instanceInfo, error) {
+	info := &instanceInfo{
+		PID:      os.Getpid(),
+		Port:     port,
+		Started:  time.Now(),
+		lockFile: filename,
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, err = f.Write(data)
+			closeErr := f.Close()
+			if err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(filename)
+				return nil, err
+			}
+			instance = info
+			return nil, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		running, err := readLock(filename)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			stat, statErr := os.Stat(filename)
+			if statErr == nil && time.Since(stat.ModTime()) < lockWriteWait {
+				return nil, errAlreadyRunning
+			}
+		} else if running.PID != os.Getpid() && processRunning(running.PID) {
+			return running, errAlreadyRunning
+		}
+
+		fmt.Printf("Removing the lock file %s left behind by a Freehold-Sync which is no longer running.\n", filename)
+		err = os.Remove(filename)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("Unable to lock %s", filename)
+}
+
+func readLock(filename string) (*instanceInfo, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	info := &instanceInfo{}
+	err = json.Unmarshal(data, info)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// unlockInstance removes this instance's lock file
+func unlockInstance() {
+	if instance == nil {
+		return
+	}
+	os.Remove(instance.lockFile)
+}
+
+// alreadyRunning returns the message explaining which instance is already running, and
+// asks that instance to show its web interface if show is set
+func alreadyRunning(running *instanceInfo, show bool) string {
+	if running == nil {
+		return "Freehold-Sync is already running with these settings, and is still starting up."
+	}
+
+	msg := fmt.Sprintf("Freehold-Sync is already running with these settings (PID %d), "+
+		"its web interface is at %s", running.PID, running.url())
+	if !show {
+		return msg
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	res, err := client.Post(running.url()+"/instance/show/", "application/json", nil)
+	if err != nil {
+		return msg + "\nUnable to open its web interface: " + err.Error()
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return msg + "\nUnable to open its web interface: " + res.Status
+	}
+	return msg + "\nOpened its web interface."
+}
+
+// openBrowser opens the passed in url in the default web browser
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "darwin":
+		cmd = exec.Command("open", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+func instanceGet(w http.ResponseWriter, r *http.Request) {
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+		Data:   instance,
+	})
+}
+
+// instanceShowPost opens the web interface in a browser on this machine, so it's only
+// accepted from another instance started on the same machine
+func instanceShowPost(w http.ResponseWriter, r *http.Request) {
+	if !fromLoopback(r) {
+		respondJsend(w, &jsend{
+			Status:  statusFail,
+			Message: "The web interface can only be opened from this machine",
+		})
+		return
+	}
+	if errHandled(openBrowser(instance.url()), w) {
+		return
+	}
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+	})
+}
+
+// fromLoopback returns whether or not the request was made from this machine
+func fromLoopback(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,137 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockInstance(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func() { instance = nil }()
+
+	// a process which has exited, so its PID isn't running
+	exited := exec.Command(os.Args[0], "-test.run=^$")
+	err = exited.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadPID := exited.Process.Pid
+
+	lock := func(pid int) []byte {
+		data, err := json.Marshal(&instanceInfo{PID: pid, Port: "6142", Started: time.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name    string
+		lock    []byte // existing lock file, none if nil
+		age     time.Duration
+		locked  bool
+		running bool // the running instance is returned
+	}{
+		{"no lock", nil, 0, true, false},
+		{"live lock", lock(os.Getppid()), 0, false, true},
+		{"own lock", lock(os.Getpid()), 0, true, false},
+		{"stale lock", lock(deadPID), 0, true, false},
+		{"lock being written", []byte("{\"pid\":"), 0, false, false},
+		{"unreadable old lock", []byte("{\"pid\":"), time.Minute, true, false},
+	}
+
+	for _, test := range tests {
+		instance = nil
+		filename := filepath.Join(dir, lockFileName)
+		os.Remove(filename)
+		if test.lock != nil {
+			err = ioutil.WriteFile(filename, test.lock, 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			modified := time.Now().Add(-test.age)
+			err = os.Chtimes(filename, modified, modified)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		running, err := lockInstance(filename, "8080")
+		if test.locked {
+			if err != nil {
+				t.Errorf("%s: locking returned %s", test.name, err)
+				continue
+			}
+			held, err := readLock(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if held.PID != os.Getpid() || held.Port != "8080" || instance == nil {
+				t.Errorf("%s: lock is held by PID %d on port %s, expected this instance", test.name, held.PID,
+					held.Port)
+			}
+			continue
+		}
+
+		if err != errAlreadyRunning {
+			t.Errorf("%s: locking returned %v, expected %s", test.name, err, errAlreadyRunning)
+		}
+		if (running != nil) != test.running {
+			t.Errorf("%s: running instance is %v, expected it to be returned %t", test.name, running, test.running)
+		}
+		if running != nil && running.Port != "6142" {
+			t.Errorf("%s: running instance is on port %s, expected 6142", test.name, running.Port)
+		}
+		data, err := ioutil.ReadFile(filename)
+		if err != nil || string(data) != string(test.lock) {
+			t.Errorf("%s: lock file was changed while held", test.name)
+		}
+	}
+}
+
+func TestFromLoopback(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		loopback   bool
+	}{
+		{"ipv4 loopback", "127.0.0.1:52000", true},
+		{"ipv6 loopback", "[::1]:52000", true},
+		{"lan address", "192.168.1.20:52000", false},
+		{"public address", "[2001:db8::1]:52000", false},
+		{"no port", "127.0.0.1", false},
+		{"host name", "localhost:52000", false},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest("POST", "/instance/show/", nil)
+		r.RemoteAddr = test.remoteAddr
+		if fromLoopback(r) != test.loopback {
+			t.Errorf("%s: loopback is %t, expected %t", test.name, !test.loopback, test.loopback)
+		}
+	}
+
+	r := httptest.NewRequest("POST", "/instance/show/", nil)
+	r.RemoteAddr = "192.168.1.20:52000"
+	w := httptest.NewRecorder()
+	instanceShowPost(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Showing the web interface from another machine responded %d, expected %d",
+			w.Code, http.StatusBadRequest)
+	}
+}
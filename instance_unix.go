@@ -0,0 +1,16 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package main
+
+import "syscall"
+
+// processRunning returns whether or not a process with the passed in PID is running
+func processRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
@@ -0,0 +1,158 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/remote"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+const bucketJournal = datastore.BucketJournal
+
+// journalEntry is a change that has been picked up, but hasn't finished syncing.
+// Entries are kept in the datastore until the change syncs or is given up on, so
+// pending changes aren't lost if freehold-sync quits or crashes
+type journalEntry struct {
+	ProfileID     string    `json:"profileID"`
+	Local         string    `json:"local"`  // local file path
+	Remote        string    `json:"remote"` // remote file path
+	LocalDeleted  bool      `json:"localDeleted"`
+	RemoteDeleted bool      `json:"remoteDeleted"`
+	LogType       string    `json:"logType"`
+	Attempts      int       `json:"attempts"`
+	Queued        time.Time `json:"queued"`
+	Original      string    `json:"original,omitempty"` // local file a suspicious upload looks like an encrypted copy of
+}
+
+func newJournalEntry(p *syncer.Profile, l, r syncer.Syncer, logType string) *journalEntry {
+	return &journalEntry{
+		ProfileID:     p.ID(),
+		Local:         l.ID(),
+		Remote:        r.(*remote.File).URL,
+		LocalDeleted:  l.Deleted(),
+		RemoteDeleted: r.Deleted(),
+		LogType:       logType,
+		Queued:        time.Now(),
+	}
+}
+
+func (j *journalEntry) key() string {
+	return j.ProfileID + "_" + j.Local + "_" + j.Remote
+}
+
+// path returns the path of the entry relative to the profile's local starting point
+func (j *journalEntry) path(p *syncer.Profile) string {
+	return filepath.ToSlash(strings.TrimPrefix(j.Local, p.Local.ID()))
+}
+
+func (j *journalEntry) save() error {
+	return datastore.Put(bucketJournal, j.key(), j)
+}
+
+func (j *journalEntry) remove() error {
+	return datastore.Delete(bucketJournal, j.key())
+}
+
+// syncers builds the local and remote syncers for the entry from their current state.
+// A file is only still treated as deleted if it hasn't since come back
+func (j *journalEntry) syncers(p *syncer.Profile) (*local.File, *remote.File, error) {
+	l, err := local.New(j.Local)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error building local syncer %s: %s", j.Local, err)
+	}
+	if j.LocalDeleted && !l.Exists() {
+		l.SetDeleted(true)
+	}
+
+	r, err := remote.New(p.Remote.(*remote.File).Client(), j.Remote)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error building remote syncer %s: %s", j.Remote, err)
+	}
+	if j.RemoteDeleted && !r.Exists() {
+		r.SetDeleted(true)
+	}
+	return l, r, nil
+}
+
+func journalEntries() ([]*journalEntry, error) {
+	var entries []*journalEntry
+	err := datastore.DB().View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketJournal)).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			j := &journalEntry{}
+			err := json.Unmarshal(v, j)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, j)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// replayJournal queues up any changes left unfinished the last time freehold-sync ran.
+// Changes for profiles which are no longer running are dropped
+func replayJournal() {
+	entries, err := journalEntries()
+	if err != nil {
+		log.Error(fmt.Sprintf("Error reading the journal of unfinished changes: %s", err), "Both")
+		return
+	}
+
+	replayed := 0
+	profiles := make(map[string]*syncer.Profile)
+	replay := make(map[string][]*journalEntry)
+	for i := range entries {
+		p := syncer.Running(entries[i].ProfileID)
+		if p == nil {
+			entries[i].remove()
+			continue
+		}
+		replayed++
+		profiles[p.ID()] = p
+		replay[p.ID()] = append(replay[p.ID()], entries[i])
+	}
+
+	// each profile's changes are replayed in the order they were queued
+	for id := range replay {
+		go resyncEntries(profiles[id], replay[id])
+	}
+
+	if replayed > 0 {
+		log.Info(fmt.Sprintf("Replaying %d changes left unfinished the last time freehold-sync ran", replayed), "Both")
+	}
+}
+
+// byQueued sorts journal entries by when they were first queued
+type byQueued []*journalEntry
+
+func (b byQueued) Len() int           { return len(b) }
+func (b byQueued) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byQueued) Less(i, j int) bool { return b[i].Queued.Before(b[j].Queued) }
+
+// syncChange syncs a journaled change
+func syncChange(p *syncer.Profile, entry *journalEntry, l, r syncer.Syncer) {
+	err := entry.save()
+	if err != nil {
+		log.Error(fmt.Sprintf("Error journaling change to %s: %s", l.ID(), err), entry.LogType)
+	}
+
+	finishChange(p, entry, p.Sync(l, r))
+}
@@ -0,0 +1,29 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestByQueued(t *testing.T) {
+	start := time.Now()
+	entries := []*journalEntry{
+		{Local: "third", Queued: start.Add(2 * time.Second)},
+		{Local: "first", Queued: start},
+		{Local: "fourth", Queued: start.Add(3 * time.Second)},
+		{Local: "second", Queued: start.Add(time.Second)},
+	}
+
+	sort.Sort(byQueued(entries))
+
+	for i, want := range []string{"first", "second", "third", "fourth"} {
+		if entries[i].Local != want {
+			t.Errorf("Entry %d is %s, expected %s", i, entries[i].Local, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"math"
+	"os"
+)
+
+// fingerprintSample is how much of the start of a file is fingerprinted
+const fingerprintSample = 64 * 1024
+
+// Fingerprint is the hash and entropy of the start of a file, for telling when a file's
+// content has been replaced with something very different, such as by being encrypted
+type Fingerprint struct {
+	Hash    string  `json:"hash"`
+	Entropy float64 `json:"entropy"` // bits per byte, from 0 for a single repeated byte to 8 for random data
+}
+
+// FingerprintOf fingerprints the local file at the passed in path
+func FingerprintOf(filePath string) (*Fingerprint, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data := make([]byte, fingerprintSample)
+	n, err := io.ReadFull(f, data)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	data = data[:n]
+
+	hash := sha256.Sum256(data)
+	return &Fingerprint{
+		Hash:    hex.EncodeToString(hash[:]),
+		Entropy: entropy(data),
+	}, nil
+}
+
+// entropy returns the shannon entropy of the data in bits per byte
+func entropy(data []byte) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	var counts [256]int
+	for i := range data {
+		counts[data[i]]++
+	}
+
+	var e float64
+	for i := range counts {
+		if counts[i] == 0 {
+			continue
+		}
+		p := float64(counts[i]) / float64(len(data))
+		e -= p * math.Log2(p)
+	}
+	return e
+}
@@ -0,0 +1,36 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestEntropy(t *testing.T) {
+	counting := make([]byte, 256*16)
+	for i := range counting {
+		counting[i] = byte(i)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		entropy float64
+	}{
+		{"empty", nil, 0},
+		{"repeated byte", bytes.Repeat([]byte{'a'}, 100), 0},
+		{"two bytes", bytes.Repeat([]byte("ab"), 50), 1},
+		{"four bytes", bytes.Repeat([]byte("abcd"), 50), 2},
+		{"every byte", counting, 8},
+	}
+
+	for _, test := range tests {
+		if e := entropy(test.data); math.Abs(e-test.entropy) > 0.0001 {
+			t.Errorf("%s: entropy is %f, expected %f", test.name, e, test.entropy)
+		}
+	}
+}
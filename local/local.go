@@ -7,6 +7,7 @@ package local
 import (
 	"errors"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +23,7 @@ type File struct {
 	info     os.FileInfo
 	exists   bool
 	deleted  bool
+	seen     time.Time // when the file info was last read
 }
 
 // New Returns a File from the local machine for use in syncing
@@ -29,6 +31,7 @@ func New(filePath string) (*File, error) {
 	f := &File{
 		filepath: filePath,
 		exists:   true,
+		seen:     time.Now(),
 	}
 
 	info, err := os.Stat(filePath)
@@ -125,6 +128,8 @@ func (f *File) Open() (io.ReadCloser, error) {
 
 // Write writes from the reader to the Syncer
 func (f *File) Write(r io.ReadCloser, size int64, modTime time.Time) error {
+	defer r.Close()
+
 	var wf *os.File
 	err := f.refresh()
 	if err != nil {
@@ -134,30 +139,70 @@ func (f *File) Write(r io.ReadCloser, size int64, modTime time.Time) error {
 	//ignore fsnotify events for this change
 	ignore.add(f.ID())
 	defer ignore.remove(f.ID())
+	defer snapshot.record(f.ID())
 
-	if f.exists {
-		wf, err = os.Open(f.ID())
-	} else {
-		wf, err = os.Create(f.ID())
+	// make sure the whole file will fit before starting, the current file is kept
+	// until the new one is completely written
+	err = checkSpace(f.ID(), size)
+	if err != nil {
+		return err
+	}
+
+	// write to a temp file in the same folder, and only replace the current file once
+	// the new one is complete, so a failed write never leaves a truncated file behind
+	wf, err = ioutil.TempFile(filepath.Dir(f.ID()), TempPrefix)
+	if err != nil {
+		return err
 	}
+	tempName := wf.Name()
+	ignore.add(tempName)
+	defer ignore.remove(tempName)
+
+	err = f.writeTemp(wf, r, size, modTime)
+	if err != nil {
+		os.Remove(tempName)
+		return err
+	}
+
+	err = os.Rename(tempName, f.filepath)
 	if err != nil {
+		os.Remove(tempName)
 		return err
 	}
 
+	return nil
+}
+
+// writeTemp copies the reader into the temp file and gives it the mode of the file
+// it's replacing, and the passed in modified time
+func (f *File) writeTemp(wf *os.File, r io.Reader, size int64, modTime time.Time) error {
 	written, err := io.Copy(wf, r)
 	if err != nil {
+		wf.Close()
 		return err
 	}
 	if written != size {
+		wf.Close()
 		return io.ErrShortWrite
 	}
 
-	err = os.Chtimes(f.filepath, time.Now(), modTime)
+	// temp files are only readable by the current user
+	mode := os.FileMode(0644)
+	if f.exists {
+		mode = f.info.Mode().Perm()
+	}
+	err = wf.Chmod(mode)
 	if err != nil {
+		wf.Close()
 		return err
 	}
 
-	return r.Close()
+	err = wf.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Chtimes(wf.Name(), time.Now(), modTime)
 }
 
 // IsDir is whether or not the file is a directory
@@ -185,6 +230,7 @@ func (f *File) Delete() error {
 	//ignore fsnotify events for this change
 	ignore.add(f.ID())
 	defer ignore.remove(f.ID())
+	defer snapshot.record(f.ID())
 
 	if f.IsDir() {
 		//Remove monitor
@@ -216,7 +262,13 @@ func (f *File) Rename() error {
 
 	newName += time.Now().Format(time.Stamp) + ext
 
-	return os.Rename(f.filepath, newName)
+	err = os.Rename(f.filepath, newName)
+	if err != nil {
+		return err
+	}
+	snapshot.record(f.filepath)
+	snapshot.record(newName)
+	return nil
 }
 
 // Size returns the size of the file
@@ -254,6 +306,7 @@ func (f *File) CreateDir() (syncer.Syncer, error) {
 	if err != nil {
 		return nil, err
 	}
+	snapshot.record(f.filepath)
 
 	return New(f.filepath)
 }
@@ -288,14 +341,13 @@ func (f *File) StartMonitor(p *syncer.Profile) error {
 	if err != nil {
 		return err
 	}
+	snapshot.set(f.ID(), children)
 
 	// Trigger initial change event to make sure all
 	// child folders are monitored recursively and all
 	// files are in sync
 	for i := range children {
-		go func(child *File) {
-			queueChange(child)
-		}(children[i])
+		p.QueueEvent(children[i], handleChange)
 	}
 
 	return watching.add(p, f)
@@ -335,8 +387,10 @@ func (f *File) waitInUse() {
 	}
 
 	for {
-		// wait 3 seconds and see if the size or modified date has changed
-		time.Sleep(3 * time.Second)
+		// wait until 3 seconds have passed since the file was last looked at, and see
+		// if the size or modified date has changed.  Events that sat in a queue
+		// for longer don't need to wait again
+		time.Sleep(f.seen.Add(3 * time.Second).Sub(time.Now()))
 		current, err := os.Stat(f.ID())
 		if err != nil {
 			//if file was deleted, or some other error happens
@@ -349,5 +403,6 @@ func (f *File) waitInUse() {
 			break
 		}
 		f.info = current
+		f.seen = time.Now()
 	}
 }
@@ -0,0 +1,68 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MarkerName is the name of the file which marks a folder as a local sync root, so a root
+// which is missing or unmounted can be told apart from one whose files were all deleted
+const MarkerName = ".freehold-sync"
+
+// TempPrefix starts the names of the temp files new versions of files are written to
+// before they replace the current ones
+const TempPrefix = MarkerName + "-"
+
+// Mark returns the ID in the root's marker file, creating the marker if it doesn't exist
+func Mark(root string) (string, error) {
+	filename := filepath.Join(root, MarkerName)
+	data, err := ioutil.ReadFile(filename)
+	if err == nil {
+		if id := strings.TrimSpace(string(data)); id != "" {
+			return id, nil
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	b := make([]byte, 16)
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	err = ioutil.WriteFile(filename, []byte(id), 0644)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// CheckMarker returns an error if the root doesn't exist, or its marker file is missing or
+// doesn't hold the passed in ID
+func CheckMarker(root, id string) error {
+	data, err := ioutil.ReadFile(filepath.Join(root, MarkerName))
+	if os.IsNotExist(err) {
+		if _, err = os.Stat(root); err != nil {
+			return fmt.Errorf("The local sync root %s is missing", root)
+		}
+		return fmt.Errorf("The local sync root %s is missing its %s marker file", root, MarkerName)
+	}
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(string(data)) != id {
+		return fmt.Errorf("The %s marker file in the local sync root %s belongs to a different root", MarkerName, root)
+	}
+	return nil
+}
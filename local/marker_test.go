@@ -0,0 +1,64 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMarker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-marker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	root := filepath.Join(dir, "root")
+	err = os.Mkdir(root, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := Mark(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id == "" {
+		t.Fatal("Marker ID is empty")
+	}
+	again, err := Mark(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != id {
+		t.Fatalf("Marking a marked root changed its ID from %s to %s", id, again)
+	}
+
+	tests := []struct {
+		name  string
+		setup func()
+		id    string
+		valid bool
+	}{
+		{"marked", func() {}, id, true},
+		{"other id", func() {}, "other", false},
+		{"marker with whitespace", func() {
+			ioutil.WriteFile(filepath.Join(root, MarkerName), []byte(id+"\n"), 0644)
+		}, id, true},
+		{"marker removed", func() { os.Remove(filepath.Join(root, MarkerName)) }, id, false},
+		{"root removed", func() { os.RemoveAll(root) }, id, false},
+	}
+
+	for _, test := range tests {
+		test.setup()
+		err := CheckMarker(root, test.id)
+		if (err == nil) != test.valid {
+			t.Errorf("%s: check returned %v, expected valid to be %t", test.name, err, test.valid)
+		}
+	}
+}
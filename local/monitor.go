@@ -5,8 +5,10 @@
 package local
 
 import (
+	"fmt"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"bitbucket.org/tshannon/freehold-sync/log"
 	"bitbucket.org/tshannon/freehold-sync/syncer"
@@ -16,29 +18,41 @@ import (
 // LogType is the log type for local syncing
 const LogType = "local"
 
+// Monitor modes determine how changes to local files are detected
+//	MonitorAuto: Poll if the folder is on a network mount, otherwise use file system events
+//	MonitorEvents: Always use file system events
+//	MonitorPolling: Always poll the folders for changes
+const (
+	MonitorAuto = iota
+	MonitorEvents
+	MonitorPolling
+)
+
 var (
 	watcher       *fsnotify.Watcher
 	changeHandler ChangeHandler
 	watching      profileFiles // folders being watched for changes
 	ignore        ignoreFiles  //File changes to ignore because they are from this process
-	changes       changeMap    //queued up changes to a given file, makes sure excessive calls to sync don't happen
+	watches       watchUsage   //tracks file system watch usage against the os limit
 )
 
+// watchLimitWarn is the percentage of the os watch limit at which a warning is logged
+const watchLimitWarn = 0.9
+
 func init() {
 	watching = profileFiles{
-		files: make(map[string][]*syncer.Profile),
+		files:  make(map[string][]*syncer.Profile),
+		polled: make(map[string]struct{}),
 	}
 	ignore = ignoreFiles{
 		files: make(map[string]struct{}),
 	}
-	changes = changeMap{
-		files: make(map[string]struct{}),
-	}
 }
 
 type profileFiles struct {
 	sync.RWMutex
-	files map[string][]*syncer.Profile
+	files  map[string][]*syncer.Profile
+	polled map[string]struct{} // folders being polled for changes instead of watched for events
 }
 
 func (p *profileFiles) add(profile *syncer.Profile, file *File) error {
@@ -55,8 +69,16 @@ func (p *profileFiles) add(profile *syncer.Profile, file *File) error {
 		// already watching, but profile is new
 		p.RUnlock()
 		p.Lock()
+		defer p.Unlock()
 		p.files[file.ID()] = append(profiles, profile)
-		p.Unlock()
+
+		if _, polled := p.polled[file.ID()]; polled && !profile.PollLocal {
+			// new profile wants file system events, if they can't be had
+			// the folder will continue to be polled
+			if watcher.Add(file.ID()) == nil {
+				delete(p.polled, file.ID())
+			}
+		}
 		return nil
 	}
 	p.RUnlock()
@@ -64,12 +86,22 @@ func (p *profileFiles) add(profile *syncer.Profile, file *File) error {
 	p.Lock()
 	defer p.Unlock()
 
-	err := watcher.Add(file.ID())
-	if err != nil {
-		return err
+	if profile.PollLocal {
+		p.polled[file.ID()] = struct{}{}
+	} else {
+		err := watcher.Add(file.ID())
+		if err != nil {
+			if !isWatchLimit(err) {
+				return err
+			}
+			// out of watches, poll this folder instead so it isn't silently unmonitored
+			p.polled[file.ID()] = struct{}{}
+			watches.reached(file.ID())
+		}
 	}
 
 	p.files[file.ID()] = []*syncer.Profile{profile}
+	watches.check(len(p.files) - len(p.polled))
 
 	return nil
 }
@@ -102,35 +134,138 @@ func (p *profileFiles) profiles(f *File) []*syncer.Profile {
 func (p *profileFiles) remove(profile *syncer.Profile, file *File) error {
 	//If profile is nil, remove all from file, and remove watch
 	// if last profile is removed, remove watch
+	p.Lock()
+	defer p.Unlock()
 
-	p.RLock()
-	if profiles, ok := p.files[file.ID()]; ok {
-		p.RUnlock()
-		p.Lock()
-		defer p.Unlock()
+	profiles, ok := p.files[file.ID()]
+	if !ok {
+		// not currently watching file
+		return nil
+	}
 
-		if profile == nil {
-			delete(p.files, file.ID())
-			return watcher.Remove(file.ID())
-		}
+	if profile == nil {
+		return p.unwatch(file.ID())
+	}
 
-		for i := range profiles {
-			if profiles[i].ID() == profile.ID() {
-				//remove profile
-				profiles = append(profiles[:i], profiles[i+1:]...)
-				break
-			}
-		}
-		if len(profiles) == 0 {
-			delete(p.files, file.ID())
-			return watcher.Remove(file.ID())
+	for i := range profiles {
+		if profiles[i].ID() == profile.ID() {
+			//remove profile
+			profiles = append(profiles[:i], profiles[i+1:]...)
+			break
 		}
 	}
-	p.RUnlock()
-	// not currently watching file
+	if len(profiles) == 0 {
+		return p.unwatch(file.ID())
+	}
+	p.files[file.ID()] = profiles
 	return nil
 }
 
+// unwatch stops watching or polling the folder, must be called under lock
+func (p *profileFiles) unwatch(dir string) error {
+	delete(p.files, dir)
+	snapshot.remove(dir)
+	if _, ok := p.polled[dir]; ok {
+		delete(p.polled, dir)
+		return nil
+	}
+	return watcher.Remove(dir)
+}
+
+// eventDirs returns the paths of all the folders currently being watched
+// for file system events
+func (p *profileFiles) eventDirs() []string {
+	p.RLock()
+	defer p.RUnlock()
+
+	dirs := make([]string, 0, len(p.files))
+	for k := range p.files {
+		if _, ok := p.polled[k]; !ok {
+			dirs = append(dirs, k)
+		}
+	}
+	return dirs
+}
+
+// polledDirs returns the paths of all the folders currently being polled
+func (p *profileFiles) polledDirs() []string {
+	p.RLock()
+	defer p.RUnlock()
+
+	dirs := make([]string, 0, len(p.polled))
+	for k := range p.polled {
+		dirs = append(dirs, k)
+	}
+	return dirs
+}
+
+// WatchStatus is the current usage of file system watches
+type WatchStatus struct {
+	Watched      int    `json:"watched"`           // folders being watched for file system events
+	Polled       int    `json:"polled"`            // folders being polled for changes
+	Limit        int    `json:"limit"`             // max number of watches allowed by the os, 0 if there is no limit
+	LimitReached bool   `json:"limitReached"`      // whether or not folders had to fall back to polling
+	Warning      string `json:"warning,omitempty"` // set if the limit has been reached or is close
+}
+
+type watchUsage struct {
+	sync.Mutex
+	limit      int
+	warned     bool
+	limitFound bool
+}
+
+// check logs a warning the first time the number of watches gets close to the limit
+func (w *watchUsage) check(count int) {
+	w.Lock()
+	defer w.Unlock()
+	if w.limit == 0 || w.warned {
+		return
+	}
+	if float64(count) >= float64(w.limit)*watchLimitWarn {
+		w.warned = true
+		log.Warn(fmt.Sprintf("%d folders are being watched, which is close to the system limit of %d watches.",
+			count, w.limit), LogType)
+	}
+}
+
+// reached logs a warning the first time the watch limit is hit
+func (w *watchUsage) reached(dir string) {
+	w.Lock()
+	defer w.Unlock()
+	if w.limitFound {
+		return
+	}
+	w.limitFound = true
+	w.warned = true
+	log.Warn(fmt.Sprintf("The system limit of file watches has been reached at %s.  It and any folders that "+
+		"can't be watched will be polled for changes instead.  Raise fs.inotify.max_user_watches and restart "+
+		"freehold-sync to watch them for events.", dir), LogType)
+}
+
+// Watches returns the current usage of file system watches
+func Watches() *WatchStatus {
+	watching.RLock()
+	status := &WatchStatus{
+		Watched: len(watching.files) - len(watching.polled),
+		Polled:  len(watching.polled),
+	}
+	watching.RUnlock()
+
+	watches.Lock()
+	defer watches.Unlock()
+	status.Limit = watches.limit
+	status.LimitReached = watches.limitFound
+
+	if status.LimitReached {
+		status.Warning = "The system limit of file watches has been reached, some folders are being polled instead."
+	} else if status.Limit > 0 && float64(status.Watched) >= float64(status.Limit)*watchLimitWarn {
+		status.Warning = "The number of watched folders is close to the system limit of file watches."
+	}
+
+	return status
+}
+
 type ignoreFiles struct {
 	sync.RWMutex
 	files map[string]struct{}
@@ -159,19 +294,34 @@ func (i *ignoreFiles) has(file string) bool {
 // ChangeHandler is the function called when a change occurs in a monitored folder
 type ChangeHandler func(*syncer.Profile, syncer.Syncer)
 
-// StartWatcher Starts local file system monitoring
-func StartWatcher(handler ChangeHandler) error {
+// StartWatcher Starts local file system monitoring.  Every scan interval all watched
+// folders are compared against their last known state to catch any changes the file
+// system events missed.  A scan interval of 0 disables the scan.  Folders of profiles
+// which poll for local changes are checked every poll interval, which can't be less than a second
+func StartWatcher(handler ChangeHandler, scan, poll time.Duration) error {
 	var err error
 	changeHandler = handler
+	scanInterval = scan
+	pollInterval = poll
+	if pollInterval < minPollInterval {
+		pollInterval = minPollInterval
+	}
+	watches.limit = maxWatches()
 	watcher, err = fsnotify.NewWatcher()
 
+	stopScan = false
+	if scanInterval > 0 {
+		scanTimer = time.AfterFunc(scanInterval, scanDirs)
+	}
+	pollTimer = time.AfterFunc(pollInterval, pollDirs)
+
 	go func() {
 		for {
 			select {
 			case event := <-watcher.Events:
 				file, err := New(event.Name)
 				if err != nil {
-					log.New(err.Error(), LogType)
+					log.Error(err.Error(), LogType)
 					continue
 				}
 				if ignore.has(file.ID()) {
@@ -185,7 +335,7 @@ func StartWatcher(handler ChangeHandler) error {
 
 			case err := <-watcher.Errors:
 				if err != nil {
-					log.New(err.Error(), LogType)
+					log.Error(err.Error(), LogType)
 				}
 			}
 		}
@@ -195,6 +345,14 @@ func StartWatcher(handler ChangeHandler) error {
 
 // StopWatcher stops the local file system monitoring
 func StopWatcher() error {
+	stopScan = true
+	if scanTimer != nil {
+		scanTimer.Stop()
+	}
+	if pollTimer != nil {
+		pollTimer.Stop()
+	}
+
 	watching.RLock()
 	defer watching.RUnlock()
 	if len(watching.files) > 0 {
@@ -204,54 +362,57 @@ func StopWatcher() error {
 	return nil
 }
 
-type changeMap struct {
-	sync.RWMutex
-	files map[string]struct{}
+// Rescan walks the entire local tree of the passed in profile and
+// calls the change handler on every file and folder found, so they can
+// be checked against the remote
+func Rescan(p *syncer.Profile) error {
+	root, err := New(p.Local.ID())
+	if err != nil {
+		return err
+	}
+	return root.rescan(p)
 }
 
-func (c *changeMap) add(f *File) {
-	c.Lock()
-	defer c.Unlock()
-	c.files[f.ID()] = struct{}{}
-}
+func (f *File) rescan(p *syncer.Profile) error {
+	children, err := f.Children()
+	if err != nil {
+		return err
+	}
 
-func (c *changeMap) remove(f *File) {
-	c.Lock()
-	defer c.Unlock()
-	delete(c.files, f.ID())
+	for i := range children {
+		changeHandler(p, children[i])
+		if children[i].IsDir() {
+			err = children[i].rescan(p)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
-func (c *changeMap) has(f *File) bool {
-	c.RLock()
-	defer c.RUnlock()
-	_, ok := c.files[f.ID()]
-	return ok
+// queueChange queues up the change on every profile watching the file's folder.
+// Events are handled in the order they arrive, and subsequent events for the same
+// file group together into one change event until the change handler is called
+func queueChange(f *File) {
+	profiles := watching.profiles(f)
+	for i := range profiles {
+		// each profile waits on its own copy of the file
+		file := *f
+		profiles[i].QueueEvent(&file, handleChange)
+	}
 }
 
-// queueChange queues up a change and waits for the file to stop
-// changing to before sending the changeHandler signal
-// Subsequent queued events for the same file will group together
-// into one change event until the change handler is called
-func queueChange(f *File) {
-	if !changes.has(f) {
-		changes.add(f)
-		defer changes.remove(f)
+// handleChange waits for the file to stop changing before sending the changeHandler signal
+func handleChange(p *syncer.Profile, s syncer.Syncer) {
+	f := s.(*File)
+	if !f.deleted {
 		f.waitInUse() // wait for the file to stop changing
+	}
 
-		profiles := watching.profiles(f)
-		for i := range profiles {
-			changeHandler(profiles[i], f)
-
-			if f.deleted {
-				f.StopMonitor(profiles[i])
-			}
-		}
-	} else if f.Deleted() {
-		//call immediatly
-		profiles := watching.profiles(f)
-		for i := range profiles {
-			changeHandler(profiles[i], f)
-			f.StopMonitor(profiles[i])
-		}
+	changeHandler(p, f)
+	if f.deleted {
+		f.StopMonitor(p)
 	}
+	snapshot.record(f.ID())
 }
@@ -0,0 +1,38 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import "syscall"
+
+// file system type names which don't reliably deliver fsevents
+var networkFSTypes = map[string]struct{}{
+	"nfs":     {},
+	"smbfs":   {},
+	"afpfs":   {},
+	"webdav":  {},
+	"cifs":    {},
+	"osxfuse": {},
+	"macfuse": {},
+}
+
+// IsNetworkMount returns whether or not the passed in path is on a network
+// file system where file system events can't be relied on
+func IsNetworkMount(filePath string) bool {
+	var st syscall.Statfs_t
+	err := syscall.Statfs(filePath, &st)
+	if err != nil {
+		return false
+	}
+
+	name := make([]byte, 0, len(st.Fstypename))
+	for _, c := range st.Fstypename {
+		if c == 0 {
+			break
+		}
+		name = append(name, byte(c))
+	}
+	_, ok := networkFSTypes[string(name)]
+	return ok
+}
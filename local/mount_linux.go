@@ -0,0 +1,32 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import "syscall"
+
+// file system types from statfs(2) which don't reliably deliver inotify events
+var networkFSTypes = map[uint32]struct{}{
+	0x6969:     {}, // NFS
+	0x517B:     {}, // SMB
+	0xFF534D42: {}, // CIFS
+	0xFE534D42: {}, // SMB2
+	0x564C:     {}, // NCP
+	0x5346414F: {}, // AFS
+	0x73757245: {}, // CODA
+	0x01021997: {}, // 9P
+	0x65735546: {}, // FUSE (sshfs, gvfs, etc)
+}
+
+// IsNetworkMount returns whether or not the passed in path is on a network
+// file system where file system events can't be relied on
+func IsNetworkMount(filePath string) bool {
+	var st syscall.Statfs_t
+	err := syscall.Statfs(filePath, &st)
+	if err != nil {
+		return false
+	}
+	_, ok := networkFSTypes[uint32(st.Type)]
+	return ok
+}
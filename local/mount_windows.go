@@ -0,0 +1,27 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+const driveRemote = 4
+
+var getDriveType = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDriveTypeW")
+
+// IsNetworkMount returns whether or not the passed in path is on a network
+// drive where file system events can't be relied on
+func IsNetworkMount(filePath string) bool {
+	root, err := syscall.UTF16PtrFromString(filepath.VolumeName(filePath) + `\`)
+	if err != nil {
+		return false
+	}
+
+	driveType, _, _ := getDriveType.Call(uintptr(unsafe.Pointer(root)))
+	return driveType == driveRemote
+}
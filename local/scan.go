@@ -0,0 +1,257 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// scanThrottle is how long a reconciliation scan waits between folders so
+// it doesn't compete with actual syncing for disk access
+const scanThrottle = 10 * time.Millisecond
+
+// minPollInterval is the shortest interval polled folders are checked at, polling
+// can't be turned off because folders fall back to it when they can't be watched
+const minPollInterval = time.Second
+
+// maxScanReport is the max number of caught paths listed in a scan's log entry
+const maxScanReport = 10
+
+var (
+	snapshot     snapshotMap // last known state of every watched folder
+	scanInterval time.Duration
+	scanTimer    *time.Timer
+	pollInterval time.Duration
+	pollTimer    *time.Timer
+	stopScan     bool
+)
+
+func init() {
+	snapshot = snapshotMap{
+		dirs: make(map[string]map[string]fileState),
+	}
+}
+
+// fileState is the last known state of a local file
+type fileState struct {
+	modified time.Time
+	size     int64
+	isDir    bool
+}
+
+func stateOf(info os.FileInfo) fileState {
+	return fileState{
+		modified: info.ModTime(),
+		size:     info.Size(),
+		isDir:    info.IsDir(),
+	}
+}
+
+func (s fileState) changed(current fileState) bool {
+	if s.isDir != current.isDir {
+		return true
+	}
+	if s.isDir {
+		// folder contents are checked when the folder itself is scanned
+		return false
+	}
+	return s.size != current.size || !s.modified.Equal(current.modified)
+}
+
+// snapshotMap holds the children of each folder as they were last seen
+type snapshotMap struct {
+	sync.RWMutex
+	dirs map[string]map[string]fileState
+}
+
+// set replaces the snapshot of a folder and returns the previous one
+func (s *snapshotMap) set(dir string, children []*File) (map[string]fileState, bool) {
+	current := make(map[string]fileState, len(children))
+	for i := range children {
+		current[children[i].ID()] = stateOf(children[i].info)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	last, ok := s.dirs[dir]
+	s.dirs[dir] = current
+	return last, ok
+}
+
+// record updates the state of a single file in its parent folder's snapshot
+// if the parent folder is in the snapshot
+func (s *snapshotMap) record(filePath string) {
+	info, err := os.Stat(filePath)
+
+	s.Lock()
+	defer s.Unlock()
+	children, ok := s.dirs[filepath.Dir(filePath)]
+	if !ok {
+		return
+	}
+	if err != nil {
+		delete(children, filePath)
+		return
+	}
+	children[filePath] = stateOf(info)
+}
+
+func (s *snapshotMap) remove(dir string) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.dirs, dir)
+}
+
+// totals returns the number of files and their total size in the passed in folder
+// and all of the folders below it
+func (s *snapshotMap) totals(root string) (files int, bytes int64) {
+	s.RLock()
+	defer s.RUnlock()
+	for dir, children := range s.dirs {
+		dir = filepath.Clean(dir)
+		if dir != root && !strings.HasPrefix(dir, root+string(filepath.Separator)) {
+			continue
+		}
+		for _, state := range children {
+			if !state.isDir {
+				files++
+				bytes += state.size
+			}
+		}
+	}
+	return files, bytes
+}
+
+// Totals returns the number of files and their total size under the local starting
+// point of the profile, as of the last time each folder was looked at
+func Totals(p *syncer.Profile) (files int, bytes int64) {
+	return snapshot.totals(filepath.Clean(p.Local.ID()))
+}
+
+// FolderTotals returns the number of files and their total size under the passed in
+// watched folder, as of the last time each folder was looked at
+func FolderTotals(dir string) (files int, bytes int64) {
+	return snapshot.totals(filepath.Clean(dir))
+}
+
+// differences returns the children of the folder which have changed since the
+// last time the folder was looked at.  Sets deleted if the file used to exist
+func (f *File) differences() ([]*File, error) {
+	if !f.IsDir() {
+		return nil, nil
+	}
+
+	children, err := f.Children()
+	if err != nil {
+		return nil, err
+	}
+
+	last, ok := snapshot.set(f.ID(), children)
+	if !ok {
+		// never seen this folder before, everything is new
+		return children, nil
+	}
+
+	var diff []*File
+	for i := range children {
+		if ignore.has(children[i].ID()) {
+			continue
+		}
+		state, found := last[children[i].ID()]
+		if !found || state.changed(stateOf(children[i].info)) {
+			diff = append(diff, children[i])
+		}
+		delete(last, children[i].ID())
+	}
+
+	// anything left wasn't found, and was deleted
+	for id := range last {
+		if ignore.has(id) {
+			continue
+		}
+		deleted, err := New(id)
+		if err != nil {
+			return nil, err
+		}
+		deleted.deleted = true
+		diff = append(diff, deleted)
+	}
+
+	return diff, nil
+}
+
+// checkDirs compares each of the passed in folders against their last known state
+// and queues up any changes found.  Returns the paths of all the changed files
+func checkDirs(dirs []string, throttle time.Duration) []string {
+	var changed []string
+
+	for i := range dirs {
+		if stopScan {
+			break
+		}
+		dir, err := New(dirs[i])
+		if err != nil {
+			log.Error(err.Error(), LogType)
+			continue
+		}
+		if !dir.IsDir() {
+			// removal will be picked up when the parent is checked
+			continue
+		}
+
+		diff, err := dir.differences()
+		if err != nil {
+			log.Error(fmt.Sprintf("Error checking %s for changes: %s", dir.ID(), err), LogType)
+			continue
+		}
+
+		for d := range diff {
+			changed = append(changed, diff[d].ID())
+			queueChange(diff[d])
+		}
+		if throttle > 0 {
+			time.Sleep(throttle)
+		}
+	}
+	return changed
+}
+
+// scanDirs does a full walk of every folder watched for file system events comparing it
+// against the last known state, and queues any changes the file system events missed
+func scanDirs() {
+	dirs := watching.eventDirs()
+	caught := checkDirs(dirs, scanThrottle)
+
+	if len(caught) > 0 {
+		report := caught
+		if len(report) > maxScanReport {
+			report = report[:maxScanReport]
+		}
+		log.Warn(fmt.Sprintf("Local scan of %d folders found %d changes missed by file system events: %s",
+			len(dirs), len(caught), strings.Join(report, ", ")), LogType)
+	}
+
+	if !stopScan {
+		scanTimer = time.AfterFunc(scanInterval, scanDirs)
+	}
+}
+
+// pollDirs checks all folders that can't rely on file system events
+// for changes
+func pollDirs() {
+	checkDirs(watching.polledDirs(), 0)
+
+	if !stopScan {
+		pollTimer = time.AfterFunc(pollInterval, pollDirs)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestDifferences(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-scan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer snapshot.remove(dir)
+
+	write := func(name, data string) {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.txt", "a")
+	write("b.txt", "b")
+	write("c.txt", "c")
+	err = os.Mkdir(filepath.Join(dir, "sub"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		change  func()
+		changed []string
+		deleted []string
+	}{
+		{"first look", func() {}, []string{"a.txt", "b.txt", "c.txt", "sub"}, nil},
+		{"unchanged", func() {}, nil, nil},
+		{"resized", func() { write("a.txt", "longer") }, []string{"a.txt"}, nil},
+		{"modified", func() {
+			err := os.Chtimes(filepath.Join(dir, "b.txt"), time.Now(), time.Now().Add(-time.Hour))
+			if err != nil {
+				t.Fatal(err)
+			}
+		}, []string{"b.txt"}, nil},
+		{"created", func() { write("d.txt", "d") }, []string{"d.txt"}, nil},
+		{"deleted", func() { os.Remove(filepath.Join(dir, "c.txt")) }, []string{"c.txt"}, []string{"c.txt"}},
+		{"folder contents", func() { write(filepath.Join("sub", "e.txt"), "e") }, nil, nil},
+	}
+
+	for _, test := range tests {
+		test.change()
+		f, err := New(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		diff, err := f.differences()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var changed, deleted []string
+		for i := range diff {
+			name := filepath.Base(diff[i].ID())
+			changed = append(changed, name)
+			if diff[i].Deleted() {
+				deleted = append(deleted, name)
+			}
+		}
+		sort.Strings(changed)
+		if !equalNames(changed, test.changed) || !equalNames(deleted, test.deleted) {
+			t.Errorf("%s: changed %v and deleted %v, expected changed %v and deleted %v", test.name, changed,
+				deleted, test.changed, test.deleted)
+		}
+	}
+
+	files, bytes := FolderTotals(dir)
+	if files != 3 || bytes != int64(len("longer")+len("b")+len("d")) {
+		t.Errorf("Folder totals are %d files and %d bytes, expected 3 files and 8 bytes", files, bytes)
+	}
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// spaceReserve is how much free space must be left on a disk after a download
+var spaceReserve int64
+
+// SetSpaceReserve sets how many bytes of free space must be left on a disk after
+// writing a file to it
+func SetSpaceReserve(bytes int64) {
+	spaceReserve = bytes
+}
+
+// SpaceError is returned when there isn't enough free space on the disk to write a file
+type SpaceError struct {
+	Path      string
+	Needed    int64
+	Available int64
+}
+
+func (e *SpaceError) Error() string {
+	return fmt.Sprintf("Not enough free space to write %s: %d bytes are needed, but only %d bytes are available "+
+		"after the %d byte reserve", e.Path, e.Needed, e.Available, spaceReserve)
+}
+
+// checkSpace returns a SpaceError if writing needed bytes to the disk of the passed in
+// path would leave less than the reserve free.  If free space can't be determined
+// the write is allowed to try
+func checkSpace(filePath string, needed int64) error {
+	free, err := freeSpace(existingDir(filePath))
+	if err != nil {
+		return nil
+	}
+	available := int64(free) - spaceReserve
+	if needed > available {
+		if available < 0 {
+			available = 0
+		}
+		return &SpaceError{
+			Path:      filePath,
+			Needed:    needed,
+			Available: available,
+		}
+	}
+	return nil
+}
+
+// HasSpace returns whether or not needed bytes can be written to the disk of the
+// passed in path and still leave the reserve free
+func HasSpace(filePath string, needed int64) bool {
+	return checkSpace(filePath, needed) == nil
+}
+
+// existingDir returns the closest folder to the path which exists
+func existingDir(filePath string) string {
+	dir := filepath.Dir(filePath)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckSpace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-space")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer SetSpaceReserve(spaceReserve)
+
+	free, err := freeSpace(dir)
+	if err != nil {
+		t.Skipf("Free space can't be determined: %s", err)
+	}
+	const margin = 64 << 20 // room for other writes to the disk while the test runs
+	if free < 4*margin {
+		t.Skipf("Only %d bytes are free", free)
+	}
+
+	tests := []struct {
+		name    string
+		reserve int64
+		file    string
+		needed  int64
+		ok      bool
+	}{
+		{"no reserve", 0, "a.txt", 1 << 20, true},
+		{"more than free", 0, "a.txt", int64(free) + margin, false},
+		{"within reserve", int64(free) - 2*margin, "a.txt", 1 << 20, true},
+		{"hits reserve", int64(free) - 2*margin, "a.txt", 3 * margin, false},
+		{"reserve over free", int64(free) + margin, "a.txt", 0, false},
+		{"missing folder", int64(free) - 2*margin, filepath.Join("missing", "b.txt"), 3 * margin, false},
+	}
+
+	for _, test := range tests {
+		SetSpaceReserve(test.reserve)
+		filePath := filepath.Join(dir, test.file)
+		err := checkSpace(filePath, test.needed)
+		if (err == nil) != test.ok {
+			t.Errorf("%s: check returned %v, expected ok to be %t", test.name, err, test.ok)
+			continue
+		}
+		if HasSpace(filePath, test.needed) != test.ok {
+			t.Errorf("%s: has space is %t, expected %t", test.name, !test.ok, test.ok)
+		}
+		if err == nil {
+			continue
+		}
+		spaceErr, ok := err.(*SpaceError)
+		if !ok {
+			t.Errorf("%s: error is %T, expected a *SpaceError", test.name, err)
+			continue
+		}
+		if spaceErr.Path != filePath || spaceErr.Needed != test.needed || spaceErr.Available < 0 {
+			t.Errorf("%s: error is for %d bytes of %s with %d available, expected %d bytes of %s", test.name,
+				spaceErr.Needed, spaceErr.Path, spaceErr.Available, test.needed, filePath)
+		}
+	}
+}
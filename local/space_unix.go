@@ -0,0 +1,20 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build !windows
+// +build !windows
+
+package local
+
+import "syscall"
+
+// freeSpace returns the number of bytes available to this user on the disk of the passed in path
+func freeSpace(dir string) (uint64, error) {
+	stat := &syscall.Statfs_t{}
+	err := syscall.Statfs(dir, stat)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
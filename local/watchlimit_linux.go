@@ -0,0 +1,33 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package local
+
+import (
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const watchLimitFile = "/proc/sys/fs/inotify/max_user_watches"
+
+// maxWatches returns the max number of inotify watches allowed for the user
+// or 0 if it can't be determined
+func maxWatches() int {
+	data, err := ioutil.ReadFile(watchLimitFile)
+	if err != nil {
+		return 0
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0
+	}
+	return limit
+}
+
+// isWatchLimit returns whether or not the error is from running out of watches
+func isWatchLimit(err error) bool {
+	return err == syscall.ENOSPC
+}
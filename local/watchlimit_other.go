@@ -0,0 +1,19 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build !linux
+// +build !linux
+
+package local
+
+// maxWatches returns the max number of watches allowed, only linux
+// has a fixed limit
+func maxWatches() int {
+	return 0
+}
+
+// isWatchLimit returns whether or not the error is from running out of watches
+func isWatchLimit(err error) bool {
+	return false
+}
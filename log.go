@@ -5,14 +5,19 @@
 package main
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"bitbucket.org/tshannon/freehold-sync/log"
 )
 
 type logInput struct {
-	Type string `json:"type"`
-	Page int    `json:"page"`
+	log.Filter
+	Page     int `json:"page"`
+	PageSize int `json:"pageSize"`
 }
 
 func logGet(w http.ResponseWriter, r *http.Request) {
@@ -22,7 +27,7 @@ func logGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logs, err := log.Get(input.Type, input.Page)
+	logs, err := log.Get(&input.Filter, input.Page, input.PageSize)
 	if errHandled(err, w) {
 		return
 	}
@@ -33,3 +38,59 @@ func logGet(w http.ResponseWriter, r *http.Request) {
 	})
 
 }
+
+type logExportInput struct {
+	log.Filter
+	Format string `json:"format"` // csv or jsonl
+}
+
+// logExportGet responds with all of the log entries matching the filter as
+// a CSV or JSON lines file, for attaching to support tickets
+func logExportGet(w http.ResponseWriter, r *http.Request) {
+	input := &logExportInput{}
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	if input.Format != "csv" && input.Format != "jsonl" {
+		errHandled(errors.New("Invalid export format. The format must be csv or jsonl."), w)
+		return
+	}
+
+	logs, err := log.Find(&input.Filter)
+	if errHandled(err, w) {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Content-Disposition", "attachment; filename=freehold-sync-log."+input.Format)
+
+	if input.Format == "jsonl" {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		encoder := json.NewEncoder(w)
+		for i := range logs {
+			if encoder.Encode(logs[i]) != nil {
+				return
+			}
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"when", "level", "type", "profile", "path", "change", "bytes", "duration", "log"})
+	for i := range logs {
+		writer.Write([]string{
+			logs[i].When,
+			logs[i].Level,
+			logs[i].Type,
+			logs[i].Profile,
+			logs[i].Path,
+			logs[i].Change,
+			strconv.FormatInt(logs[i].Bytes, 10),
+			logs[i].Duration.String(),
+			logs[i].Log,
+		})
+	}
+	writer.Flush()
+}
@@ -2,14 +2,19 @@
 // Use of this source code is governed by the MIT license
 // that can be found in the LICENSE file.
 
-// Package log will log last 1000 items in a datstore file
-// an the system log.  New records will push old records out
-// of the datastore, but they will remain in the system log for
-// the users system to manage
+// Package log records log entries in the datastore, where the most recent
+// entries can be viewed from the web interface.  Entries can also be copied to
+// sinks such as the system log, a rotating log file or stderr, where they will
+// remain for the user's system to manage
 package log
 
 import (
+	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/boltdb/bolt"
@@ -19,88 +24,316 @@ import (
 
 const (
 	bucket   = datastore.BucketLog
-	maxRows  = 10000
 	pageSize = 25
+	// maxTrim is the most old entries removed when a new entry is logged, so lowering
+	// the retention limits doesn't hold up logging while the excess is removed
+	maxTrim = 100
 )
 
+// Log levels, entries below the minimum level aren't logged
+const (
+	LevelDebug = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+var (
+	minLevel   = LevelInfo
+	maxEntries = 10000
+	maxAge     time.Duration
+)
+
+// ParseLevel returns the log level for the passed in level name
+func ParseLevel(name string) (int, error) {
+	for i := range levelNames {
+		if strings.EqualFold(levelNames[i], strings.TrimSpace(name)) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid log level %s, must be one of %s", name, strings.Join(levelNames, ", "))
+}
+
+// SetLevel sets the minimum level of entries which will be logged
+func SetLevel(level int) {
+	minLevel = level
+}
+
+// SetRetention sets how many log entries are kept, and how long they are kept for.  An
+// entries limit of 0 keeps any number of entries, and an age of 0 keeps entries of any age
+func SetRetention(entries int, age time.Duration) {
+	maxEntries = entries
+	maxAge = age
+}
+
 // Log is a log entry
 type Log struct {
-	When string `json:"when"`
-	Type string `json:"type"`
-	Log  string `json:"log"`
+	When  string `json:"when"`
+	Type  string `json:"type"`
+	Level string `json:"level"`
+	Log   string `json:"log"`
+	Fields
 }
 
-// New inserts a new log entry
-func New(entry, Type string) {
+// Fields are optional structured details of what a log entry is about
+type Fields struct {
+	Profile  string        `json:"profile,omitempty"`  // ID of the sync profile
+	Path     string        `json:"path,omitempty"`     // path relative to the sync profile's starting point
+	Change   string        `json:"change,omitempty"`   // type of change: write, delete, rename or createDir
+	Bytes    int64         `json:"bytes,omitempty"`    // number of bytes transferred
+	Duration time.Duration `json:"duration,omitempty"` // how long the change took in nanoseconds
+}
+
+// Debug logs a debug entry
+func Debug(entry, Type string, fields ...Fields) {
+	write(LevelDebug, entry, Type, fields)
+}
+
+// Info logs an info entry
+func Info(entry, Type string, fields ...Fields) {
+	write(LevelInfo, entry, Type, fields)
+}
+
+// Warn logs a warning entry
+func Warn(entry, Type string, fields ...Fields) {
+	write(LevelWarn, entry, Type, fields)
+}
+
+// Error logs an error entry
+func Error(entry, Type string, fields ...Fields) {
+	write(LevelError, entry, Type, fields)
+}
+
+// write inserts a new log entry.  If the entry can't be written to the datastore
+// it's written to stderr instead
+func write(level int, entry, Type string, fields []Fields) {
+	if level < minLevel {
+		return
+	}
+
 	when := time.Now().Format(time.RFC3339)
 
 	log := &Log{
-		When: when,
-		Type: Type,
-		Log:  entry,
+		When:  when,
+		Type:  Type,
+		Level: levelNames[level],
+		Log:   entry,
 	}
-
-	err := datastore.Put(bucket, when+"_"+Type, log)
-	if err != nil {
-		panic("Error can't log entry to freehold-sync log. Entry: " +
-			entry + " error: " + err.Error())
-		return
+	if len(fields) > 0 {
+		log.Fields = fields[0]
 	}
 
-	err = trimOldLogs()
+	err := insert(log)
 	if err != nil {
-		panic("Error can't trim old log entries: " +
-			entry + " error: " + err.Error())
-		return
+		fmt.Fprintf(os.Stderr, "%s (Error writing to the freehold-sync log: %s)\n", log, err)
+	}
+	sinks.write(log)
+}
 
+// String formats the log entry as a single line of text
+func (l *Log) String() string {
+	line := fmt.Sprintf("%s %s [%s] %s", l.When, l.Level, l.Type, l.Log)
+	if l.Profile != "" {
+		line += fmt.Sprintf(" profile=%q", l.Profile)
+	}
+	if l.Path != "" {
+		line += fmt.Sprintf(" path=%q", l.Path)
+	}
+	if l.Change != "" {
+		line += " change=" + l.Change
+	}
+	if l.Bytes != 0 {
+		line += fmt.Sprintf(" bytes=%d", l.Bytes)
 	}
+	if l.Duration != 0 {
+		line += " duration=" + l.Duration.String()
+	}
+	return line
 }
 
-type key []byte
+func insert(log *Log) error {
+	if datastore.DB() == nil {
+		return errors.New("The datastore isn't open")
+	}
+
+	value, err := json.Marshal(log)
+	if err != nil {
+		return err
+	}
 
-func trimOldLogs() error {
 	return datastore.DB().Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(bucket))
-		c := b.Cursor()
-		count := 0
-
-		for k, _ := c.First(); k != nil; k, _ = c.Next() {
-			count++
-			if count > maxRows {
-				err := c.Delete()
-				if err != nil {
-					return err
-				}
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		err = b.Put(datastore.SequenceKey(seq), value)
+		if err != nil {
+			return err
+		}
+		return trimOldLogs(b, seq)
+	})
+}
+
+// trimOldLogs removes the oldest entries which are past the retention limits.  Keys are
+// sequential, so the number of entries is the distance between the first and last keys
+func trimOldLogs(b *bolt.Bucket, last uint64) error {
+	c := b.Cursor()
+	cutoff := time.Now().Add(-maxAge)
+
+	for i := 0; i < maxTrim; i++ {
+		k, v := c.First()
+		if k == nil {
+			return nil
+		}
+
+		count := last - binary.BigEndian.Uint64(k) + 1
+		if maxEntries <= 0 || count <= uint64(maxEntries) {
+			if maxAge <= 0 {
+				return nil
+			}
+			l := &Log{}
+			if json.Unmarshal(v, l) != nil {
+				return nil
+			}
+			when, err := time.Parse(time.RFC3339, l.When)
+			if err != nil || when.After(cutoff) {
+				return nil
 			}
 		}
 
-		return nil
-	})
+		err := c.Delete()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter limits which log entries are retrieved, empty values match all entries
+type Filter struct {
+	Type    string    `json:"type"`
+	Level   string    `json:"level"`   // minimum level
+	Profile string    `json:"profile"` // profile ID
+	Path    string    `json:"path"`    // matches paths starting with this path
+	Change  string    `json:"change"`
+	From    time.Time `json:"from"`   // matches entries logged at or after this time
+	To      time.Time `json:"to"`     // matches entries logged before this time
+	Search  string    `json:"search"` // matches entries containing this text, ignoring case
+
+	level int
+}
+
+// prepare validates the filter before it's matched against entries
+func (f *Filter) prepare() error {
+	f.level = LevelDebug
+	if f.Level != "" {
+		var err error
+		f.level, err = ParseLevel(f.Level)
+		if err != nil {
+			return err
+		}
+	}
+	f.Search = strings.ToLower(f.Search)
+	return nil
 }
 
-// Get retrieves the logs for a given type / page
-// if type is "" then return all logs of all types
-func Get(Type string, page int) ([]*Log, error) {
-	skip := page * pageSize
-	logs := make([]*Log, 0, pageSize)
+func (f *Filter) match(l *Log) bool {
+	if f.Type != "" && l.Type != f.Type {
+		return false
+	}
+	if f.Level != "" {
+		entryLevel, err := ParseLevel(l.Level)
+		if err == nil && entryLevel < f.level {
+			return false
+		}
+	}
+	if f.Profile != "" && l.Profile != f.Profile {
+		return false
+	}
+	if f.Path != "" && !strings.HasPrefix(l.Path, f.Path) {
+		return false
+	}
+	if f.Change != "" && l.Change != f.Change {
+		return false
+	}
+	if !f.From.IsZero() || !f.To.IsZero() {
+		when, err := time.Parse(time.RFC3339, l.When)
+		if err != nil {
+			return false
+		}
+		if !f.From.IsZero() && when.Before(f.From) {
+			return false
+		}
+		if !f.To.IsZero() && !when.Before(f.To) {
+			return false
+		}
+	}
+	if f.Search != "" && !strings.Contains(strings.ToLower(l.Log), f.Search) &&
+		!strings.Contains(strings.ToLower(l.Path), f.Search) {
+		return false
+	}
+	return true
+}
+
+// before returns whether or not the entry was logged before the filter's time range
+func (f *Filter) before(l *Log) bool {
+	if f.From.IsZero() {
+		return false
+	}
+	when, err := time.Parse(time.RFC3339, l.When)
+	return err == nil && when.Before(f.From)
+}
+
+func unmarshalLog(v []byte) (*Log, error) {
+	l := &Log{}
+	err := json.Unmarshal(v, l)
+	if err != nil {
+		return nil, err
+	}
+	if l.Level == "" {
+		// entries from before levels were all errors
+		l.Level = levelNames[LevelError]
+	}
+	return l, nil
+}
 
-	err := datastore.DB().View(func(tx *bolt.Tx) error {
+// Get retrieves a page of the logs matching the filter, newest first.  A page size of 0
+// uses the default page size
+func Get(filter *Filter, page, size int) ([]*Log, error) {
+	if size <= 0 {
+		size = pageSize
+	}
+	skip := page * size
+	logs := []*Log{}
+
+	err := filter.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	err = datastore.DB().View(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(bucket))
 		c := b.Cursor()
 
 		for k, v := c.Last(); k != nil; k, v = c.Prev() {
-			l := &Log{}
-			err := json.Unmarshal(v, l)
+			l, err := unmarshalLog(v)
 			if err != nil {
 				return err
 			}
-			if Type != "" && l.Type != Type {
+			if filter.before(l) {
+				// entries are in the order they were logged, so the rest are older
+				break
+			}
+			if !filter.match(l) {
 				continue
 			}
 
 			if skip <= 0 {
 				logs = append(logs, l)
-				if len(logs) >= pageSize {
+				if len(logs) >= size {
 					break
 				}
 			} else {
@@ -116,3 +349,32 @@ func Get(Type string, page int) ([]*Log, error) {
 
 	return logs, nil
 }
+
+// Find retrieves all of the logs matching the filter, oldest first
+func Find(filter *Filter) ([]*Log, error) {
+	logs := []*Log{}
+
+	err := filter.prepare()
+	if err != nil {
+		return nil, err
+	}
+
+	err = datastore.DB().View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucket)).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			l, err := unmarshalLog(v)
+			if err != nil {
+				return err
+			}
+			if filter.match(l) {
+				logs = append(logs, l)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
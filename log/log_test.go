@@ -0,0 +1,182 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package log
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+)
+
+func TestFilterMatch(t *testing.T) {
+	entry := &Log{
+		Type:  "remote",
+		Level: "warn",
+		Log:   "Error uploading file",
+		Fields: Fields{
+			Profile: "profile",
+			Path:    "/docs/report.txt",
+			Change:  "write",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		match  bool
+	}{
+		{"empty", Filter{}, true},
+		{"type", Filter{Type: "remote"}, true},
+		{"other type", Filter{Type: "local"}, false},
+		{"lower level", Filter{Level: "info"}, true},
+		{"same level", Filter{Level: "WARN"}, true},
+		{"higher level", Filter{Level: "error"}, false},
+		{"profile", Filter{Profile: "profile"}, true},
+		{"other profile", Filter{Profile: "other"}, false},
+		{"path prefix", Filter{Path: "/docs/"}, true},
+		{"other path", Filter{Path: "/pictures/"}, false},
+		{"change", Filter{Change: "write"}, true},
+		{"other change", Filter{Change: "delete"}, false},
+		{"all", Filter{Type: "remote", Level: "warn", Profile: "profile", Path: "/docs", Change: "write"}, true},
+		{"all but one", Filter{Type: "remote", Level: "warn", Profile: "profile", Path: "/docs", Change: "delete"},
+			false},
+	}
+
+	for _, test := range tests {
+		filter := test.filter
+		err := filter.prepare()
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if filter.match(entry) != test.match {
+			t.Errorf("%s: match is %t, expected %t", test.name, !test.match, test.match)
+		}
+	}
+
+	filter := &Filter{Level: "loud"}
+	if filter.prepare() == nil {
+		t.Error("Filter with an invalid level was prepared")
+	}
+}
+
+func TestTrimOldLogs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+	defer SetRetention(maxEntries, maxAge)
+
+	old := time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+	tests := []struct {
+		name    string
+		entries int
+		age     time.Duration
+		old     int // entries logged two hours ago, before the rest
+		logged  int
+		kept    int
+	}{
+		{"under limit", 10, 0, 0, 5, 5},
+		{"over limit", 10, 0, 0, 15, 10},
+		{"no limit", 0, 0, 0, 15, 15},
+		{"old entries", 0, time.Hour, 5, 5, 5},
+		{"old entries over limit", 3, time.Hour, 5, 5, 3},
+		{"old entries kept", 0, 3 * time.Hour, 5, 5, 10},
+	}
+
+	for _, test := range tests {
+		err = datastore.DB().Update(func(tx *bolt.Tx) error {
+			err := tx.DeleteBucket([]byte(bucket))
+			if err != nil {
+				return err
+			}
+			_, err = tx.CreateBucket([]byte(bucket))
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		SetRetention(test.entries, test.age)
+
+		for i := 0; i < test.old+test.logged; i++ {
+			l := &Log{When: time.Now().Format(time.RFC3339), Type: "local", Level: "info", Log: fmt.Sprint(i)}
+			if i < test.old {
+				l.When = old
+			}
+			err = insert(l)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		logs, err := Find(&Filter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(logs) != test.kept {
+			t.Errorf("%s: kept %d entries, expected %d", test.name, len(logs), test.kept)
+			continue
+		}
+		// the newest entries are kept
+		if last := logs[len(logs)-1].Log; last != fmt.Sprint(test.old+test.logged-1) {
+			t.Errorf("%s: newest entry is %s, expected %d", test.name, last, test.old+test.logged-1)
+		}
+	}
+}
+
+func TestFilterTimeAndSearch(t *testing.T) {
+	when := time.Date(2015, 6, 1, 10, 0, 0, 0, time.UTC)
+	entry := &Log{
+		When:   when.Format(time.RFC3339),
+		Type:   "local",
+		Level:  "error",
+		Log:    "Error writing file",
+		Fields: Fields{Path: "/docs/Report.txt"},
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		match  bool
+		before bool
+	}{
+		{"from before", Filter{From: when.Add(-time.Minute)}, true, false},
+		{"from at", Filter{From: when}, true, false},
+		{"from after", Filter{From: when.Add(time.Minute)}, false, true},
+		{"to after", Filter{To: when.Add(time.Minute)}, true, false},
+		{"to at", Filter{To: when}, false, false},
+		{"range", Filter{From: when.Add(-time.Minute), To: when.Add(time.Minute)}, true, false},
+		{"search log", Filter{Search: "WRITING"}, true, false},
+		{"search path", Filter{Search: "report"}, true, false},
+		{"search missing", Filter{Search: "upload"}, false, false},
+	}
+
+	for _, test := range tests {
+		filter := test.filter
+		err := filter.prepare()
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if filter.match(entry) != test.match {
+			t.Errorf("%s: match is %t, expected %t", test.name, !test.match, test.match)
+		}
+		if filter.before(entry) != test.before {
+			t.Errorf("%s: before is %t, expected %t", test.name, !test.before, test.before)
+		}
+	}
+}
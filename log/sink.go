@@ -0,0 +1,194 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var sinks sinkList
+
+// Sink receives a copy of every log entry at or above the minimum log level
+type Sink interface {
+	Write(l *Log) error
+	Close() error
+}
+
+type sinkList struct {
+	sync.Mutex
+	sinks []Sink
+}
+
+func (s *sinkList) write(l *Log) {
+	s.Lock()
+	defer s.Unlock()
+	for i := range s.sinks {
+		err := s.sinks[i].Write(l)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s (Error writing to log sink: %s)\n", l, err)
+		}
+	}
+}
+
+// AddSink adds a sink which will receive all new log entries
+func AddSink(sink Sink) {
+	sinks.Lock()
+	defer sinks.Unlock()
+	sinks.sinks = append(sinks.sinks, sink)
+}
+
+// CloseSinks closes and removes all log sinks
+func CloseSinks() {
+	sinks.Lock()
+	defer sinks.Unlock()
+	for i := range sinks.sinks {
+		sinks.sinks[i].Close()
+	}
+	sinks.sinks = nil
+}
+
+type stderrSink struct{}
+
+// NewStderrSink returns a sink which writes log entries to stderr
+func NewStderrSink() Sink {
+	return stderrSink{}
+}
+
+func (stderrSink) Write(l *Log) error {
+	_, err := fmt.Fprintln(os.Stderr, l)
+	return err
+}
+
+func (stderrSink) Close() error {
+	return nil
+}
+
+// fileSink writes log entries to a file, which is rotated when it gets too large or too old
+type fileSink struct {
+	filename  string
+	jsonLines bool
+	maxSize   int64
+	maxAge    time.Duration
+	file      *os.File
+	size      int64
+	opened    time.Time
+}
+
+// NewFileSink returns a sink which writes log entries to the passed in file, either as plain
+// text or as one JSON object per line.  The file is rotated when it grows past maxSize bytes
+// or is older than maxAge, and rotated files older than maxAge are removed.  A maxSize or
+// maxAge of 0 disables that limit
+func NewFileSink(filename string, jsonLines bool, maxSize int64, maxAge time.Duration) (Sink, error) {
+	f := &fileSink{
+		filename:  filename,
+		jsonLines: jsonLines,
+		maxSize:   maxSize,
+		maxAge:    maxAge,
+	}
+	err := f.open()
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *fileSink) open() error {
+	file, err := os.OpenFile(f.filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.opened = info.ModTime()
+	if f.size == 0 {
+		f.opened = time.Now()
+	}
+	return nil
+}
+
+func (f *fileSink) Write(l *Log) error {
+	var line []byte
+	if f.jsonLines {
+		data, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		line = append(data, '\n')
+	} else {
+		line = []byte(l.String() + "\n")
+	}
+
+	if f.needsRotate(int64(len(line))) {
+		err := f.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	return err
+}
+
+func (f *fileSink) needsRotate(next int64) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.maxSize > 0 && f.size+next > f.maxSize {
+		return true
+	}
+	return f.maxAge > 0 && time.Since(f.opened) > f.maxAge
+}
+
+// rotate moves the current log file aside with the time it was rotated, and starts a new one
+func (f *fileSink) rotate() error {
+	err := f.file.Close()
+	if err != nil {
+		return err
+	}
+
+	rotated := f.filename + "." + time.Now().Format("20060102-150405")
+	err = os.Rename(f.filename, rotated)
+	if err != nil {
+		return err
+	}
+
+	f.removeOld()
+	return f.open()
+}
+
+// removeOld removes rotated log files older than the max age
+func (f *fileSink) removeOld() {
+	if f.maxAge <= 0 {
+		return
+	}
+	rotated, err := filepath.Glob(f.filename + ".*")
+	if err != nil {
+		return
+	}
+	for i := range rotated {
+		info, err := os.Stat(rotated[i])
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) > f.maxAge {
+			os.Remove(rotated[i])
+		}
+	}
+}
+
+func (f *fileSink) Close() error {
+	return f.file.Close()
+}
@@ -0,0 +1,95 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileSinkRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	entry := &Log{When: time.Now().Format(time.RFC3339), Type: "local", Level: "info", Log: "entry"}
+	line := int64(len(entry.String()) + 1)
+	old := time.Now().Add(-2 * time.Hour)
+
+	tests := []struct {
+		name    string
+		maxSize int64
+		maxAge  time.Duration
+		age     time.Duration // age of the existing log file
+		written int
+		lines   int // lines in the current log file
+		rotated int // rotated log files kept
+	}{
+		{"under size", 3 * line, 0, 0, 3, 3, 0},
+		{"over size", 2 * line, 0, 0, 3, 1, 1},
+		{"no limits", 0, 0, 0, 10, 10, 0},
+		{"under age", 0, time.Hour, 0, 1, 2, 2},
+		// the rotated file's last entry is as old as the file, so it's removed with the other old one
+		{"over age", 0, time.Hour, 2 * time.Hour, 1, 1, 1},
+	}
+
+	for _, test := range tests {
+		filename := filepath.Join(dir, strings.Replace(test.name, " ", "-", -1)+".log")
+		if test.maxAge > 0 {
+			// an existing log entry, and rotated files from before and within the max age
+			err = ioutil.WriteFile(filename, []byte(entry.String()+"\n"), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = os.Chtimes(filename, time.Now().Add(-test.age), time.Now().Add(-test.age))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, rotated := range []string{".20000101-000000", ".20000102-000000"} {
+				err = ioutil.WriteFile(filename+rotated, nil, 0600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			err = os.Chtimes(filename+".20000101-000000", old, old)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		sink, err := NewFileSink(filename, false, test.maxSize, test.maxAge)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < test.written; i++ {
+			err = sink.Write(entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		sink.Close()
+
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lines := strings.Count(string(data), "\n"); lines != test.lines {
+			t.Errorf("%s: log file has %d lines, expected %d", test.name, lines, test.lines)
+		}
+		rotated, err := filepath.Glob(filename + ".*")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rotated) != test.rotated {
+			t.Errorf("%s: %d rotated log files were kept, expected %d", test.name, len(rotated), test.rotated)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build windows || plan9
+// +build windows plan9
+
+package log
+
+import "errors"
+
+// NewSyslogSink isn't supported on systems without syslog
+func NewSyslogSink(tag string) (Sink, error) {
+	return nil, errors.New("Logging to syslog isn't supported on this system")
+}
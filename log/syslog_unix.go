@@ -0,0 +1,44 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+//go:build !windows && !plan9
+// +build !windows,!plan9
+
+package log
+
+import "log/syslog"
+
+// syslogSink writes log entries to the local system log, which is also
+// collected by journald on systems which use it
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink returns a sink which writes log entries to the local syslog socket
+func NewSyslogSink(tag string) (Sink, error) {
+	writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{writer: writer}, nil
+}
+
+func (s *syslogSink) Write(l *Log) error {
+	// the system log records its own timestamp
+	line := l.String()[len(l.When)+1:]
+	switch l.Level {
+	case levelNames[LevelDebug]:
+		return s.writer.Debug(line)
+	case levelNames[LevelInfo]:
+		return s.writer.Info(line)
+	case levelNames[LevelWarn]:
+		return s.writer.Warning(line)
+	default:
+		return s.writer.Err(line)
+	}
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
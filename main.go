@@ -5,6 +5,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
@@ -14,9 +15,11 @@ import (
 	"path/filepath"
 	"runtime"
 	"strconv"
+	"syscall"
 	"time"
 
 	"bitbucket.org/tshannon/config"
+	"bitbucket.org/tshannon/freehold-sync/activity"
 	"bitbucket.org/tshannon/freehold-sync/datastore"
 	"bitbucket.org/tshannon/freehold-sync/local"
 	"bitbucket.org/tshannon/freehold-sync/log"
@@ -26,28 +29,37 @@ import (
 )
 
 var (
-	flagPort     = 6080
-	httpTimeout  time.Duration
-	server       *http.Server
-	retry        chan retrier
-	flagSkipTray = true
+	flagPort        = 6080
+	flagShow        = true
+	httpTimeout     time.Duration
+	shutdownTimeout = 30 * time.Second
+	server          *http.Server
+	retries         *retryScheduler
+	breaker         *circuitBreaker
+	flagSkipTray    = true
 )
 
 func init() {
 	flag.IntVar(&flagPort, "port", 6080, "Default Port to host freehold-sync webserver on.")
 	flag.BoolVar(&flagSkipTray, "skipTray", false, "Whether or not to skip starting the system tray.")
+	flag.BoolVar(&flagShow, "show", true, "Whether or not to open the web interface of the running instance, "+
+		"if Freehold-Sync is already running.")
 
 	//Capture program shutdown, to make sure everything shuts down nicely
 	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
-		for sig := range c {
-			if sig == os.Interrupt {
-				halt("Freehold-Sync shutting down")
-			}
-		}
+		<-c
+		go shutdown()
+
+		// a second signal exits without waiting for running changes, which are left in the journal
+		<-c
+		fmt.Fprintln(os.Stderr, "Freehold-Sync stopping without waiting for running changes to finish")
+		unlockInstance()
+		os.Exit(1)
 	}()
-	retry = make(chan retrier, 100)
+	retries = newRetryScheduler()
+	breaker = newCircuitBreaker()
 }
 
 func main() {
@@ -64,72 +76,127 @@ func main() {
 	}
 
 	port := strconv.Itoa(cfg.Int("port", flagPort))
-	remotePolling := time.Duration(cfg.Int("remotePollingSeconds", 30)) * time.Second
+	remotePoll := remote.PollSettings{
+		Interval:    time.Duration(cfg.Int("remotePollingSeconds", 30)) * time.Second,
+		MaxInterval: time.Duration(cfg.Int("remotePollingMaxSeconds", 300)) * time.Second,
+		FullCycles:  cfg.Int("remoteFullPollCycles", 10),
+		Workers:     cfg.Int("remotePollingWorkers", 4),
+	}
+	healthCheck := time.Duration(cfg.Int("remoteHealthCheckSeconds", 30)) * time.Second
+	localScan := time.Duration(cfg.Int("localScanMinutes", 60)) * time.Minute
+	localPolling := time.Duration(cfg.Int("localPollingSeconds", 30)) * time.Second
 	httpTimeout = time.Duration(cfg.Int("httpTimeoutSeconds", 0)) * time.Second
+	shutdownTimeout = time.Duration(cfg.Int("shutdownTimeoutSeconds", 30)) * time.Second
+	logLevel, err := log.ParseLevel(cfg.String("logLevel", "info"))
+	if err != nil {
+		halt(err.Error())
+	}
+	log.SetLevel(logLevel)
+	log.SetRetention(cfg.Int("logMaxEntries", 10000), time.Duration(cfg.Int("logMaxAgeDays", 0))*24*time.Hour)
+	activity.SetRetention(cfg.Int("activityMaxEntries", 50000),
+		time.Duration(cfg.Int("activityMaxAgeDays", 90))*24*time.Hour)
+	local.SetSpaceReserve(int64(cfg.Int("localSpaceReserveMB", 100)) * 1024 * 1024)
 	dataDir := filepath.Dir(cfg.FileName())
 
+	running, err := lockInstance(filepath.Join(dataDir, lockFileName), port)
+	if err == errAlreadyRunning {
+		halt(alreadyRunning(running, flagShow))
+	}
+	if err != nil {
+		halt("Error locking the data folder: " + err.Error())
+	}
+
+	if cfg.Bool("logToStderr", false) {
+		log.AddSink(log.NewStderrSink())
+	}
+	if cfg.Bool("logToSyslog", false) {
+		sink, err := log.NewSyslogSink("freehold-sync")
+		if err != nil {
+			halt("Error starting syslog logging: " + err.Error())
+		}
+		log.AddSink(sink)
+	}
+	switch logFile := cfg.String("logFileFormat", ""); logFile {
+	case "":
+	case "text", "json":
+		sink, err := log.NewFileSink(filepath.Join(dataDir, "freehold-sync.log"), logFile == "json",
+			int64(cfg.Int("logFileMaxMB", 10))*1024*1024, time.Duration(cfg.Int("logFileMaxAgeDays", 7))*24*time.Hour)
+		if err != nil {
+			halt("Error opening log file: " + err.Error())
+		}
+		log.AddSink(sink)
+	default:
+		halt("Invalid logFileFormat " + logFile + ", must be text or json")
+	}
+
 	fmt.Printf("Freehold-Sync is currently using the file %s for settings.\n", cfg.FileName())
 
 	if flagSkipTray {
-		startServer(port, dataDir, remotePolling)
+		startServer(port, dataDir, remotePoll, healthCheck, localScan, localPolling)
 	} else {
 		runtime.LockOSThread()
 
 		go func() {
 			trayhost.SetURL("http://localhost:" + port)
-			startServer(port, dataDir, remotePolling)
+			startServer(port, dataDir, remotePoll, healthCheck, localScan, localPolling)
 		}()
 
 		trayhost.EnterLoop("Freehold-Sync", getIconData())
 		//tray is exited
-		halt("Freehold-Sync shutting down")
+		shutdown()
 	}
 }
 
-func startServer(port, dataDir string, remotePolling time.Duration) {
+func startServer(port, dataDir string, remotePoll remote.PollSettings, healthCheck, localScan,
+	localPolling time.Duration) {
 	err := datastore.Open(filepath.Join(dataDir, "sync.ds"))
 	if err != nil {
 		halt(err.Error())
 	}
 
-	server := &http.Server{
+	server = &http.Server{
 		Addr:    ":" + port,
 		Handler: rootHandler,
 	}
 
-	err = local.StartWatcher(localChanges)
+	err = local.StartWatcher(localChanges, localScan, localPolling)
 	if err != nil {
 		halt("Error starting up local file monitor: " + err.Error())
 	}
 
-	err = remote.StartWatcher(remoteChanges, remotePolling)
+	err = remote.StartWatcher(remoteChanges, remotePoll)
 	if err != nil {
 		halt("Error starting up remote file monitor: " + err.Error())
 	}
+	remote.StartConnectivityMonitor(healthCheck, drainHeld)
 
 	all, err := allProfiles()
 	if err != nil {
 		halt(err.Error())
 	}
 
-	retryPoll()
-
 	for i := range all {
 		if all[i].Active {
 			prf, err := all[i].makeProfile()
 			if err != nil {
-				log.New(fmt.Sprintf("Error starting profile: %s", err.Error()), "Both")
+				log.Error(fmt.Sprintf("Error starting profile: %s", err.Error()), "Both")
 				continue
 			}
-			err = prf.Start()
+			err = all[i].start(prf)
 			if err != nil {
-				log.New(fmt.Sprintf("Error starting profile: %s", err.Error()), "Both")
+				log.Error(fmt.Sprintf("Error starting profile: %s", err.Error()), "Both")
 				continue
 			}
 		}
 	}
 
+	replayJournal()
+
 	err = server.ListenAndServe()
+	if err == http.ErrServerClosed {
+		// shutdown exits once everything has stopped
+		select {}
+	}
 	if err != nil {
 		halt(err.Error())
 	}
@@ -142,20 +209,22 @@ func localChanges(p *syncer.Profile, s syncer.Syncer) {
 
 	r, err := remote.New(p.Remote.(*remote.File).Client(), rPath)
 	if err != nil {
-		log.New(fmt.Sprintf("Error building remote syncer for local syncer %s Error: %s", s.ID(), err.Error()), local.LogType)
+		entry := &journalEntry{
+			ProfileID:    p.ID(),
+			Local:        s.ID(),
+			Remote:       rPath,
+			LocalDeleted: s.Deleted(),
+			LogType:      local.LogType,
+			Queued:       time.Now(),
+		}
+		if holdIfOffline(p, entry, err) {
+			return
+		}
+		log.Error(fmt.Sprintf("Error building remote syncer for local syncer %s Error: %s", s.ID(), err.Error()), local.LogType)
 		return
 	}
 
-	err = p.Sync(s, r)
-	if err != nil {
-		retry <- &syncRetry{
-			profile:       p,
-			local:         s,
-			remote:        r,
-			logType:       local.LogType,
-			originalError: err,
-		}
-	}
+	syncChange(p, newJournalEntry(p, s, r, local.LogType), s, r)
 }
 
 func remoteChanges(p *syncer.Profile, s syncer.Syncer) {
@@ -164,27 +233,46 @@ func remoteChanges(p *syncer.Profile, s syncer.Syncer) {
 
 	l, err := local.New(lPath)
 	if err != nil {
-		log.New(fmt.Sprintf("Error building local syncer for remote syncer %s Error: %s", s.ID(), err.Error()), remote.LogType)
+		log.Error(fmt.Sprintf("Error building local syncer for remote syncer %s Error: %s", s.ID(), err.Error()), remote.LogType)
 		return
 	}
-	err = p.Sync(l, s)
-	if err != nil {
-		retry <- &syncRetry{
-			profile:       p,
-			local:         l,
-			remote:        s,
-			logType:       remote.LogType,
-			originalError: err,
-		}
+	syncChange(p, newJournalEntry(p, l, s, remote.LogType), l, s)
+}
+
+// shutdown stops freehold-sync cleanly.  No new changes are picked up, changes that are
+// already running are given time to finish, and changes still waiting are left in the
+// journal to run the next time freehold-sync starts
+func shutdown() {
+	fmt.Println("Freehold-Sync shutting down")
+	local.StopWatcher()
+	remote.StopWatcher()
+	retries.close()
+
+	if !syncer.Shutdown(shutdownTimeout) {
+		log.Warn(fmt.Sprintf("Shutting down before all running changes finished, after waiting %s.  "+
+			"They will be synced the next time Freehold-Sync starts.", shutdownTimeout), "Both")
 	}
+
+	if server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		server.Shutdown(ctx)
+		cancel()
+	}
+
+	datastore.Close()
+	log.CloseSinks()
+	unlockInstance()
+	os.Exit(0)
 }
 
 func halt(msg string) {
 	time.Sleep(1 * time.Second)
 	fmt.Fprintln(os.Stderr, msg)
 	datastore.Close()
-	close(retry)
+	log.CloseSinks()
+	retries.close()
 	local.StopWatcher()
 	remote.StopWatcher()
+	unlockInstance()
 	os.Exit(1)
 }
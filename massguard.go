@@ -0,0 +1,398 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/activity"
+	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/remote"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// When a profile deletes or overwrites more files within a short window than its limits
+// allow, such as after an accidental rm -rf, the rest of those changes are held until they
+// are confirmed or rejected.  Confirmed changes are run, and rejected changes are undone
+// by restoring the files from the other side
+const (
+	massChangeFiles    = 100         // files deleted or overwritten on one side within the window
+	massChangePercent  = 30          // percent of the files on one side deleted or overwritten within the window
+	massChangeMinFiles = 10          // files that must change before the percent limit applies
+	massChangeWindow   = time.Minute // default window changes are counted over
+
+	// why a profile's changes are being held
+	holdMassChange = "mass change"
+	holdSuspicious = "suspicious changes"
+)
+
+// massChangeLimits are a profile's limits on deleting or overwriting files.  Zero uses the
+// default, and -1 turns the limit off
+type massChangeLimits struct {
+	MassChangeFiles         int `json:"massChangeFiles"`
+	MassChangePercent       int `json:"massChangePercent"`
+	MassChangeWindowSeconds int `json:"massChangeWindowSeconds"`
+}
+
+func (l massChangeLimits) files() int {
+	if l.MassChangeFiles == 0 {
+		return massChangeFiles
+	}
+	return l.MassChangeFiles
+}
+
+func (l massChangeLimits) percent() int {
+	if l.MassChangePercent == 0 {
+		return massChangePercent
+	}
+	return l.MassChangePercent
+}
+
+func (l massChangeLimits) window() time.Duration {
+	if l.MassChangeWindowSeconds <= 0 {
+		return massChangeWindow
+	}
+	return time.Duration(l.MassChangeWindowSeconds) * time.Second
+}
+
+var batches batchGuard
+
+func init() {
+	batches = batchGuard{
+		profiles: make(map[string]*batchState),
+	}
+}
+
+// massChangeError is returned for deletes and overwrites held for confirmation
+type massChangeError struct {
+	profileID string
+}
+
+func (e *massChangeError) Error() string {
+	return fmt.Sprintf("Too many files are being deleted or overwritten by profile %s, "+
+		"the change is held until it's confirmed", e.profileID)
+}
+
+type batchState struct {
+	start    time.Time
+	counts   map[string]int // deletes and overwrites in the window by direction
+	totals   map[string]int // files on each side when the window started
+	holding  string         // why changes are being held, empty if they aren't
+	since    time.Time      // when changes started being held
+	approved time.Time      // confirmed changes aren't checked until
+	entries  []*journalEntry
+
+	suspects     []*journalEntry // suspicious uploads waiting to see if more follow
+	suspectCount int             // suspicious uploads in the current window
+	suspectTimer *time.Timer     // releases the suspects once the window is over
+	cleared      map[string]bool // local files whose next upload isn't checked
+	originals    map[string]bool // local files with suspicious looking copies, their deletes are held with them
+	rejected     map[string]bool // originals of rejected suspicious copies, their deletes are undone
+}
+
+func (s *batchState) reset(now time.Time) {
+	s.start = now
+	s.counts = make(map[string]int)
+	s.totals = make(map[string]int)
+}
+
+type batchGuard struct {
+	sync.Mutex
+	profiles map[string]*batchState
+}
+
+// state returns the profile's batch state, must be called under lock
+func (g *batchGuard) state(profileID string) *batchState {
+	s, ok := g.profiles[profileID]
+	if !ok {
+		s = &batchState{
+			cleared:   make(map[string]bool),
+			originals: make(map[string]bool),
+			rejected:  make(map[string]bool),
+		}
+		s.reset(time.Now())
+		g.profiles[profileID] = s
+	}
+	return s
+}
+
+// heldBatch is a profile's changes waiting for confirmation
+type heldBatch struct {
+	Profile string          `json:"profile"`
+	Reason  string          `json:"reason"`
+	Since   time.Time       `json:"since"`
+	Changes []*journalEntry `json:"changes"`
+}
+
+// checkDestructive returns the check run before each of the profile's deletes and overwrites
+func (p *profileStore) checkDestructive(profile *syncer.Profile) func(change string, to syncer.Syncer) error {
+	limits := p.massChangeLimits
+	return func(change string, to syncer.Syncer) error {
+		err := batches.checkQuarantine(profile, change, to)
+		if err != nil {
+			return err
+		}
+		if limits.files() < 0 && limits.percent() < 0 {
+			return nil
+		}
+		direction := activity.DirectionDown
+		if strings.HasPrefix(to.ID(), profile.Remote.ID()) {
+			direction = activity.DirectionUp
+		}
+		return batches.check(profile, limits, direction, changedFiles(profile, direction, to))
+	}
+}
+
+// changedFiles returns how many files deleting or overwriting the passed in file changes.
+// Deleting a folder deletes every file under it
+func changedFiles(p *syncer.Profile, direction string, to syncer.Syncer) int {
+	if !to.IsDir() {
+		return 1
+	}
+
+	var files int
+	if direction == activity.DirectionDown {
+		files, _ = local.FolderTotals(to.ID())
+	} else {
+		var err error
+		files, _, err = remote.FolderTotals(to.ID())
+		if err != nil {
+			log.Error(fmt.Sprintf("Error counting the remote files under %s: %s", to.ID(), err), "Both")
+		}
+	}
+	if files < 1 {
+		return 1
+	}
+	return files
+}
+
+// check counts the passed in number of files deleted or overwritten in the direction, and
+// returns a massChangeError if the profile is over its limits
+func (g *batchGuard) check(p *syncer.Profile, limits massChangeLimits, direction string, files int) error {
+	g.Lock()
+	defer g.Unlock()
+
+	now := time.Now()
+	s := g.state(p.ID())
+	if s.holding != "" {
+		return &massChangeError{p.ID()}
+	}
+	if now.Before(s.approved) {
+		return nil
+	}
+	if now.Sub(s.start) > limits.window() {
+		s.reset(now)
+	}
+
+	if _, ok := s.totals[direction]; !ok {
+		s.totals[direction] = sideFiles(p, direction)
+	}
+	s.counts[direction] += files
+	count, total := s.counts[direction], s.totals[direction]
+
+	over := limits.files() >= 0 && count > limits.files()
+	if !over && limits.percent() >= 0 && count >= massChangeMinFiles && total > 0 {
+		over = count*100 > total*limits.percent()
+	}
+	if !over {
+		return nil
+	}
+
+	s.holding = holdMassChange
+	s.since = now
+	side := "local"
+	if direction == activity.DirectionUp {
+		side = "remote"
+	}
+	log.Warn(fmt.Sprintf("HOLDING CHANGES FOR CONFIRMATION: %d of the %d %s files of profile %s were deleted or "+
+		"overwritten within %s.  Further deletes and overwrites are held until they are confirmed or rejected.",
+		count, total, side, p.ID(), limits.window()), "Both", log.Fields{Profile: p.ID()})
+	return &massChangeError{p.ID()}
+}
+
+// sideFiles returns the number of files on the side of the profile changes in the direction change
+func sideFiles(p *syncer.Profile, direction string) int {
+	if direction == activity.DirectionDown {
+		files, _ := local.Totals(p)
+		return files
+	}
+	files, _, err := remote.Totals(p)
+	if err != nil {
+		log.Error(fmt.Sprintf("Error counting the remote files of profile %s: %s", p.ID(), err), "Both")
+	}
+	return files
+}
+
+// holdIfMassChange holds the change for confirmation if it was held back for being one
+// of too many deletes or overwrites, or while the profile is quarantined
+func holdIfMassChange(p *syncer.Profile, entry *journalEntry, err error) bool {
+	switch unwrapError(err).(type) {
+	case *massChangeError, *quarantineError:
+	default:
+		return false
+	}
+
+	saveErr := entry.save()
+	if saveErr != nil {
+		log.Error(fmt.Sprintf("Error journaling change to %s: %s", entry.Local, saveErr), entry.LogType)
+	}
+
+	batches.Lock()
+	defer batches.Unlock()
+	s, ok := batches.profiles[p.ID()]
+	if !ok || s.holding == "" {
+		// confirmed or rejected while this change was running
+		go resyncEntries(p, []*journalEntry{entry})
+		return true
+	}
+	s.entries = append(s.entries, entry)
+	return true
+}
+
+// holding returns the number of changes the profile is holding for confirmation, and
+// why it's holding them, which is empty if it isn't
+func (g *batchGuard) holding(profileID string) (int, string) {
+	g.Lock()
+	defer g.Unlock()
+	s, ok := g.profiles[profileID]
+	if !ok {
+		return 0, ""
+	}
+	return len(s.entries), s.holding
+}
+
+// held returns the changes waiting for confirmation, optionally limited to a single profile
+func (g *batchGuard) held(profileID string) []*heldBatch {
+	g.Lock()
+	defer g.Unlock()
+	var held []*heldBatch
+	for id, s := range g.profiles {
+		if s.holding == "" || (profileID != "" && profileID != id) {
+			continue
+		}
+		held = append(held, &heldBatch{
+			Profile: id,
+			Reason:  s.holding,
+			Since:   s.since,
+			Changes: append([]*journalEntry(nil), s.entries...),
+		})
+	}
+	sort.Slice(held, func(i, j int) bool { return held[i].Since.Before(held[j].Since) })
+	return held
+}
+
+// release stops holding the profile's changes, and returns the changes that were held
+func (g *batchGuard) release(profileID string, confirmed bool, window time.Duration) []*journalEntry {
+	g.Lock()
+	defer g.Unlock()
+	s, ok := g.profiles[profileID]
+	if !ok || s.holding == "" {
+		return nil
+	}
+	if s.holding == holdSuspicious {
+		if !confirmed {
+			// deletes of the originals still waiting to run are undone when they do
+			for original := range s.originals {
+				s.rejected[original] = true
+			}
+		}
+		s.originals = make(map[string]bool)
+		syncer.ResumeFrom(profileID, pauseSuspicious)
+	}
+	entries := s.entries
+	s.entries = nil
+	s.holding = ""
+	s.reset(time.Now())
+	if confirmed {
+		s.approved = time.Now().Add(window)
+	}
+	return entries
+}
+
+// confirm runs the changes held for the profile.  Deletes and overwrites in the rest of
+// the profile's window aren't held again
+func (p *profileStore) confirm() {
+	entries := batches.release(p.ID, true, p.massChangeLimits.window())
+	if len(entries) == 0 {
+		return
+	}
+	log.Info(fmt.Sprintf("%d held changes for profile %s were confirmed", len(entries), p.ID), "Both",
+		log.Fields{Profile: p.ID})
+
+	profile := syncer.Running(p.ID)
+	if profile == nil {
+		for i := range entries {
+			entries[i].remove()
+		}
+		return
+	}
+	go resyncEntries(profile, entries)
+}
+
+// reject undoes the changes held for the profile, by copying the files they would have
+// deleted or overwritten back over the side the changes came from
+func (p *profileStore) reject() {
+	entries := batches.release(p.ID, false, 0)
+	if len(entries) == 0 {
+		return
+	}
+	log.Info(fmt.Sprintf("%d held changes for profile %s were rejected", len(entries), p.ID), "Both",
+		log.Fields{Profile: p.ID})
+
+	profile := syncer.Running(p.ID)
+	if profile == nil {
+		for i := range entries {
+			entries[i].remove()
+		}
+		return
+	}
+
+	// restore folders before the files in them
+	sort.Slice(entries, func(i, j int) bool { return len(entries[i].Local) < len(entries[j].Local) })
+	go func() {
+		for i := range entries {
+			restoreEntry(profile, entries[i])
+		}
+	}()
+}
+
+// restoreEntry undoes a change by making the file it changed match the file the change came from.
+// The original of a suspicious looking copy is restored as well
+func restoreEntry(p *syncer.Profile, entry *journalEntry) {
+	defer entry.remove()
+	if entry.Original != "" {
+		defer restoreEntry(p, &journalEntry{
+			ProfileID: entry.ProfileID,
+			Local:     entry.Original,
+			Remote:    path.Join(path.Dir(entry.Remote), filepath.Base(entry.Original)),
+			LogType:   local.LogType,
+		})
+	}
+
+	l, r, err := entry.syncers(p)
+	if err != nil {
+		log.Error(fmt.Sprintf("Error restoring %s: %s", entry.Local, err), entry.LogType)
+		return
+	}
+
+	if entry.LogType == local.LogType {
+		err = p.Restore(r, l)
+	} else {
+		err = p.Restore(l, r)
+	}
+	if err != nil {
+		log.Error(fmt.Sprintf("Error restoring %s: %s", entry.Local, err), entry.LogType, log.Fields{
+			Profile: p.ID(),
+			Path:    entry.path(p),
+		})
+	}
+}
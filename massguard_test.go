@@ -0,0 +1,112 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/activity"
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// startingPoint is a sync starting point which only knows its ID
+type startingPoint struct {
+	syncer.Syncer
+	id string
+}
+
+func (s *startingPoint) ID() string {
+	return s.id
+}
+
+func TestBatchGuardCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-massguard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+
+	p := &syncer.Profile{
+		Local:  &startingPoint{id: "/home/user/docs"},
+		Remote: &startingPoint{id: "https://host/v1/file/docs/"},
+	}
+
+	tests := []struct {
+		name     string
+		limits   massChangeLimits
+		total    int   // files on the changed side
+		changes  []int // files changed by each delete or overwrite
+		held     int   // index of the first change held, -1 if none are
+		approved bool
+	}{
+		{"at files limit", massChangeLimits{}, 10000, repeatChanges(1, massChangeFiles), -1, false},
+		{"over files limit", massChangeLimits{}, 10000, repeatChanges(1, massChangeFiles+5), massChangeFiles, false},
+		{"folder delete over files limit", massChangeLimits{}, 10000, []int{150}, 0, false},
+		{"folder deletes add up", massChangeLimits{}, 10000, []int{60, 30, 20, 1}, 2, false},
+		{"custom files limit", massChangeLimits{MassChangeFiles: 5}, 10000, repeatChanges(1, 8), 5, false},
+		{"files limit off", massChangeLimits{MassChangeFiles: -1}, 10000, []int{500}, -1, false},
+		{"over percent", massChangeLimits{}, 20, repeatChanges(1, 12), massChangeMinFiles - 1, false},
+		{"folder delete over percent", massChangeLimits{}, 40, []int{15}, 0, false},
+		{"under percent minimum", massChangeLimits{}, 5, repeatChanges(1, 5), -1, false},
+		{"custom percent", massChangeLimits{MassChangePercent: 50}, 40, []int{15, 5, 1}, 2, false},
+		{"percent off", massChangeLimits{MassChangePercent: -1}, 40, []int{40}, -1, false},
+		{"unknown total", massChangeLimits{}, 0, []int{50}, -1, false},
+		{"confirmed", massChangeLimits{}, 10000, []int{500}, -1, true},
+	}
+
+	for _, test := range tests {
+		g := &batchGuard{profiles: make(map[string]*batchState)}
+		s := g.state(p.ID())
+		s.totals[activity.DirectionUp] = test.total
+		if test.approved {
+			s.approved = time.Now().Add(time.Minute)
+		}
+
+		held := -1
+		for i := range test.changes {
+			err := g.check(p, test.limits, activity.DirectionUp, test.changes[i])
+			if err == nil {
+				if held >= 0 {
+					t.Errorf("%s: change %d ran after change %d was held", test.name, i, held)
+				}
+				continue
+			}
+			if _, ok := err.(*massChangeError); !ok {
+				t.Fatalf("%s: unexpected error %s", test.name, err)
+			}
+			if held < 0 {
+				held = i
+			}
+		}
+		if held != test.held {
+			t.Errorf("%s: first change held was %d, expected %d", test.name, held, test.held)
+		}
+		if held >= 0 && s.holding != holdMassChange {
+			t.Errorf("%s: holding is %q, expected %q", test.name, s.holding, holdMassChange)
+		}
+		if s.counts[activity.DirectionDown] != 0 {
+			t.Errorf("%s: uploads were counted as downloads", test.name)
+		}
+	}
+}
+
+func repeatChanges(files, times int) []int {
+	changes := make([]int, times)
+	for i := range changes {
+		changes[i] = files
+	}
+	return changes
+}
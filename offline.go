@@ -0,0 +1,83 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/remote"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+var held heldChanges
+
+func init() {
+	held = heldChanges{
+		profiles: make(map[string][]*journalEntry),
+	}
+}
+
+// heldChanges are journaled changes waiting for their profile's remote instance to come back online
+type heldChanges struct {
+	sync.Mutex
+	profiles map[string][]*journalEntry
+}
+
+func (h *heldChanges) add(profileID string, entry *journalEntry) {
+	h.Lock()
+	defer h.Unlock()
+	h.profiles[profileID] = append(h.profiles[profileID], entry)
+}
+
+func (h *heldChanges) take(profileID string) []*journalEntry {
+	h.Lock()
+	defer h.Unlock()
+	entries := h.profiles[profileID]
+	delete(h.profiles, profileID)
+	return entries
+}
+
+// holdIfOffline holds the change until the profile's remote instance is back online if
+// the error was caused by the instance going offline.  Held changes don't count as failures
+func holdIfOffline(p *syncer.Profile, entry *journalEntry, err error) bool {
+	transient, _ := classifyError(err)
+	if !transient || remote.CheckConnection(p) {
+		return false
+	}
+
+	saveErr := entry.save()
+	if saveErr != nil {
+		log.Error(fmt.Sprintf("Error journaling change to %s: %s", entry.Local, saveErr), entry.LogType)
+	}
+	held.add(p.ID(), entry)
+
+	if !remote.IsOffline(p) {
+		// came back online while the change was being held
+		drainHeld(p)
+	}
+	return true
+}
+
+// drainHeld syncs all of the changes held for the profile while its remote instance was offline
+func drainHeld(p *syncer.Profile) {
+	go resyncEntries(p, held.take(p.ID()))
+}
+
+// resyncEntries syncs the passed in journaled changes again, one at a time in the order they
+// were first queued, so that later changes to the same file aren't overwritten by earlier ones
+func resyncEntries(p *syncer.Profile, entries []*journalEntry) {
+	sort.Sort(byQueued(entries))
+	for i := range entries {
+		l, r, err := entries[i].syncers(p)
+		if err != nil {
+			finishChange(p, entries[i], err)
+			continue
+		}
+		syncChange(p, entries[i], l, r)
+	}
+}
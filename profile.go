@@ -5,12 +5,19 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
+
+	"bitbucket.org/tshannon/freehold-sync/activity"
+	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/remote"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
 )
 
-/*profile:
+/*
+profile:
 Post: Post new Sync Profile
 Put: Update existing Sync Profile
 */
@@ -52,7 +59,8 @@ func profilePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	profile, err := newProfile(input.Name, input.Direction, input.ConflictResolution, input.ConflictDurationSeconds, input.Active,
-		input.Ignore, input.LocalPath, input.RemotePath, input.Client)
+		input.Ignore, input.LocalPath, input.RemotePath, input.Client, input.LocalMonitor,
+		input.RemotePollingSeconds, input.massChangeLimits)
 	if errHandled(err, w) {
 		return
 	}
@@ -65,13 +73,18 @@ func profilePost(w http.ResponseWriter, r *http.Request) {
 }
 
 func profilePut(w http.ResponseWriter, r *http.Request) {
-	input := &profileStore{}
+	var input json.RawMessage
 
-	if errHandled(parseJSON(r, input), w) {
+	if errHandled(parseJSON(r, &input), w) {
 		return
 	}
 
-	if errHandled(input.update(), w) {
+	profile, err := updatedProfile(input)
+	if errHandled(err, w) {
+		return
+	}
+
+	if errHandled(profile.update(), w) {
 		return
 	}
 
@@ -99,10 +112,16 @@ func profileStatusGet(w http.ResponseWriter, r *http.Request) {
 	}
 
 	count, status := profile.status()
+	failures, err := failureCount(profile.ID)
+	if errHandled(err, w) {
+		return
+	}
+	held, _ := batches.holding(profile.ID)
 
 	respondJsend(w, &jsend{
 		Status: statusSuccess,
-		Data:   map[string]interface{}{"status": status, "count": count},
+		Data: map[string]interface{}{"status": status, "count": count, "queued": syncer.QueueLength(profile.ID),
+			"failures": failures, "held": held, "watches": local.Watches()},
 	})
 }
 
@@ -130,3 +149,179 @@ func profileDelete(w http.ResponseWriter, r *http.Request) {
 		Status: statusSuccess,
 	})
 }
+
+// profileControlInput is used for controlling one or all
+// running profiles
+type profileControlInput struct {
+	ID  string `json:"id"`
+	All bool   `json:"all"`
+}
+
+// controlProfiles returns the profiles the control input applies to
+func controlProfiles(input *profileControlInput) ([]*profileStore, error) {
+	if input.All {
+		return allProfiles()
+	}
+
+	if strings.TrimSpace(input.ID) == "" {
+		return nil, errors.New("No ID specified. You must specify a profile ID, or all profiles.")
+	}
+
+	profile, err := getProfile(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return []*profileStore{profile}, nil
+}
+
+func profilePausePost(w http.ResponseWriter, r *http.Request) {
+	setProfilesPaused(w, r, true)
+}
+
+func profileResumePost(w http.ResponseWriter, r *http.Request) {
+	setProfilesPaused(w, r, false)
+}
+
+func setProfilesPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	input := &profileControlInput{}
+
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	profiles, err := controlProfiles(input)
+	if errHandled(err, w) {
+		return
+	}
+
+	for i := range profiles {
+		if errHandled(profiles[i].setPaused(paused), w) {
+			return
+		}
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+	})
+}
+
+func profileSyncNowPost(w http.ResponseWriter, r *http.Request) {
+	input := &profileControlInput{}
+
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	if strings.TrimSpace(input.ID) == "" {
+		errHandled(errors.New("No ID specified. You must specify a profile ID."), w)
+		return
+	}
+
+	profile, err := getProfile(input.ID)
+	if errHandled(err, w) {
+		return
+	}
+
+	if errHandled(profile.syncNow(), w) {
+		return
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+	})
+}
+
+func profileRemarkPost(w http.ResponseWriter, r *http.Request) {
+	input := &profileControlInput{}
+
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	if strings.TrimSpace(input.ID) == "" {
+		errHandled(errors.New("No ID specified. You must specify a profile ID."), w)
+		return
+	}
+
+	profile, err := getProfile(input.ID)
+	if errHandled(err, w) {
+		return
+	}
+
+	if errHandled(profile.remark(), w) {
+		return
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+		Data:   profile,
+	})
+}
+
+// profileStatsInput selects a profile and how many days and weeks of stats to retrieve
+type profileStatsInput struct {
+	ID    string `json:"id"`
+	Days  int    `json:"days"`
+	Weeks int    `json:"weeks"`
+}
+
+// rootTotals are the number of files and their total size under a profile's starting point
+type rootTotals struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+}
+
+func profileStatsGet(w http.ResponseWriter, r *http.Request) {
+	input := &profileStatsInput{}
+
+	if errHandled(parseJSON(r, input), w) {
+		return
+	}
+
+	if strings.TrimSpace(input.ID) == "" {
+		errHandled(errors.New("No ID specified. You must specify a profile ID when getting stats."), w)
+		return
+	}
+	if input.Days <= 0 {
+		input.Days = 30
+	}
+	if input.Weeks <= 0 {
+		input.Weeks = 12
+	}
+
+	profile, err := getProfile(input.ID)
+	if errHandled(err, w) {
+		return
+	}
+
+	daily, err := activity.Daily(profile.ID, input.Days)
+	if errHandled(err, w) {
+		return
+	}
+	weekly, err := activity.Weekly(profile.ID, input.Weeks)
+	if errHandled(err, w) {
+		return
+	}
+
+	data := map[string]interface{}{
+		"daily":  daily,
+		"weekly": weekly,
+	}
+
+	if running := syncer.Running(profile.ID); running != nil {
+		localTotals := &rootTotals{}
+		localTotals.Files, localTotals.Bytes = local.Totals(running)
+		remoteTotals := &rootTotals{}
+		remoteTotals.Files, remoteTotals.Bytes, err = remote.Totals(running)
+		if errHandled(err, w) {
+			return
+		}
+		data["local"] = localTotals
+		data["remote"] = remoteTotals
+	}
+
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+		Data:   data,
+	})
+}
@@ -8,14 +8,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/boltdb/bolt"
 
+	"bitbucket.org/tshannon/freehold-sync/activity"
 	"bitbucket.org/tshannon/freehold-sync/datastore"
 	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/log"
 	"bitbucket.org/tshannon/freehold-sync/remote"
 	"bitbucket.org/tshannon/freehold-sync/syncer"
 )
@@ -33,12 +36,18 @@ type profileStore struct {
 	LocalPath               string   `json:"localPath"`
 	RemotePath              string   `json:"remotePath"`
 	ID                      string   `json:"id"`
+	LocalMonitor            int      `json:"localMonitor"`
+	RemotePollingSeconds    int      `json:"remotePollingSeconds"`
 	Active                  bool     `json:"active"`
+	Paused                  bool     `json:"paused"`
 	Client                  *client  `json:"client"`
+	RootMarker              string   `json:"rootMarker"`   // ID in the marker files of the starting points
+	RemoteMarked            bool     `json:"remoteMarked"` // whether the remote starting point has its marker file
+	massChangeLimits
 }
 
 func newProfile(name string, direction, conflictResolution, conflictDurationSeconds int, active bool, ignore []string,
-	localPath, remotePath string, remoteClient *client) (*profileStore, error) {
+	localPath, remotePath string, remoteClient *client, localMonitor, remotePollingSeconds int, limits massChangeLimits) (*profileStore, error) {
 	ps := &profileStore{
 		ConflictResolution:      conflictResolution,
 		Direction:               direction,
@@ -49,6 +58,9 @@ func newProfile(name string, direction, conflictResolution, conflictDurationSeco
 		RemotePath:              remotePath,
 		Client:                  remoteClient,
 		ConflictDurationSeconds: conflictDurationSeconds,
+		LocalMonitor:            localMonitor,
+		RemotePollingSeconds:    remotePollingSeconds,
+		massChangeLimits:        limits,
 	}
 
 	_, err := ps.makeProfile()
@@ -82,6 +94,33 @@ func getProfile(id string) (*profileStore, error) {
 	return ps, nil
 }
 
+// updatedProfile returns the stored profile with the passed in JSON update applied to it, so
+// settings left out of the update keep their stored values
+func updatedProfile(update []byte) (*profileStore, error) {
+	input := &profileStore{}
+	if len(update) > 0 {
+		err := json.Unmarshal(update, input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ps, err := getProfile(input.ID)
+	if err == datastore.ErrNotFound {
+		return input, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(update) > 0 {
+		err = json.Unmarshal(update, ps)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ps, nil
+}
+
 func allProfiles() ([]*profileStore, error) {
 	var all []*profileStore
 	err := datastore.DB().View(func(tx *bolt.Tx) error {
@@ -127,6 +166,16 @@ func (p *profileStore) makeProfile() (*syncer.Profile, error) {
 		return nil, errors.New("Invalid sync profile conflict resolution")
 	}
 
+	if p.LocalMonitor != local.MonitorAuto &&
+		p.LocalMonitor != local.MonitorEvents &&
+		p.LocalMonitor != local.MonitorPolling {
+		return nil, errors.New("Invalid sync profile local monitor mode")
+	}
+
+	if p.RemotePollingSeconds < 0 {
+		return nil, errors.New("Invalid sync profile remote polling seconds")
+	}
+
 	var ignore []*regexp.Regexp
 
 	//validate regex
@@ -160,12 +209,25 @@ func (p *profileStore) makeProfile() (*syncer.Profile, error) {
 		return nil, fmt.Errorf("Remote sync path does not exist!")
 	}
 
+	// root markers aren't synced, including the remote markers of other clients
+	ignore = append(ignore, regexp.MustCompile("^"+regexp.QuoteMeta(filepath.Join(lFile.ID(), local.MarkerName))+"$"))
+	ignore = append(ignore, regexp.MustCompile("^"+regexp.QuoteMeta(filepath.Join(lFile.ID(), remote.MarkerPrefix))+"[0-9a-f]+$"))
+	// as do files still being written
+	ignore = append(ignore, regexp.MustCompile(regexp.QuoteMeta(string(filepath.Separator)+local.TempPrefix)+"[0-9]+$"))
+
+	pollLocal := p.LocalMonitor == local.MonitorPolling
+	if p.LocalMonitor == local.MonitorAuto {
+		pollLocal = local.IsNetworkMount(lFile.ID())
+	}
+
 	profile := &syncer.Profile{
 		Name:               p.Name,
 		Direction:          p.Direction,
 		ConflictResolution: p.ConflictResolution,
 		ConflictDuration:   time.Duration(p.ConflictDurationSeconds) * time.Second,
 		Ignore:             ignore,
+		PollLocal:          pollLocal,
+		RemotePollInterval: time.Duration(p.RemotePollingSeconds) * time.Second,
 		Local:              lFile,
 		Remote:             rFile,
 	}
@@ -181,6 +243,17 @@ func (p *profileStore) update() error {
 		return err
 	}
 
+	stored, err := getProfile(oldID)
+	if err != nil && err != datastore.ErrNotFound {
+		return err
+	}
+	if stored != nil {
+		// pausing and marking are only changed through their own actions
+		p.Paused = stored.Paused
+		p.RootMarker = stored.RootMarker
+		p.RemoteMarked = stored.RemoteMarked
+	}
+
 	if oldID != "" && oldID != profile.ID() {
 		//ID changed, check if an existing profile
 		// is already syncing these paths
@@ -193,6 +266,17 @@ func (p *profileStore) update() error {
 		if err != nil {
 			return err
 		}
+		// the starting points changed, so they are marked like a new profile's
+		p.RootMarker = ""
+		p.RemoteMarked = false
+		stored = nil
+	}
+
+	if stored == nil {
+		err = p.mark(profile)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = profile.Stop()
@@ -206,14 +290,149 @@ func (p *profileStore) update() error {
 	}
 
 	if p.Active {
-		return profile.Start()
+		return p.start(profile)
+	}
+	return nil
+}
+
+// start starts the passed in sync profile, applying the stored
+// paused state before any changes can run
+func (p *profileStore) start(profile *syncer.Profile) error {
+	if p.RootMarker == "" {
+		log.Warn(fmt.Sprintf("Profile %s has no marker files in its sync roots, so a missing root can't be "+
+			"told apart from deleted files.  Re-mark the profile to add them.", p.Name), "Both",
+			log.Fields{Profile: p.ID})
+	}
+	profile.CheckRoots = p.checkRoots(profile)
+	profile.CheckWrite = p.checkWrite(profile)
+	profile.CheckDestructive = p.checkDestructive(profile)
+
+	if p.Paused {
+		syncer.Pause(p.ID)
+	} else {
+		syncer.Resume(p.ID)
+	}
+	return profile.Start()
+}
+
+// mark writes the marker files into the profile's starting points, which are checked before
+// its changes run.  Roots are only marked when a profile is created or its paths change, and
+// when asked to with remark, so a missing root is never marked as a side effect of saving
+func (p *profileStore) mark(profile *syncer.Profile) error {
+	marker, err := local.Mark(profile.Local.ID())
+	if err != nil {
+		return fmt.Errorf("Error marking the local sync path: %s", err)
 	}
+
+	rFile := profile.Remote.(*remote.File)
+	if p.RemoteMarked && p.RootMarker != marker {
+		// the old remote marker no longer matches the local one
+		err = remote.Unmark(rFile, p.RootMarker)
+		if err != nil {
+			return fmt.Errorf("Error removing the old marker from the remote sync path: %s", err)
+		}
+	}
+	p.RootMarker = marker
+	p.RemoteMarked = false
+
+	err = remote.Mark(rFile, marker)
+	if err != nil {
+		return fmt.Errorf("Error marking the remote sync path: %s", err)
+	}
+	p.RemoteMarked = true
+	return nil
+}
+
+// remark marks the profile's current starting points as its roots, releasing any changes
+// held while a root was unavailable, and restarts the profile
+func (p *profileStore) remark() error {
+	profile, err := p.makeProfile()
+	if err != nil {
+		return err
+	}
+
+	err = p.mark(profile)
+	if err != nil {
+		return err
+	}
+
+	err = datastore.Put(bucket, p.ID, p)
+	if err != nil {
+		return err
+	}
+	unavailable.release(p.ID)
+	return p.update()
+}
+
+// setPaused pauses or resumes running changes on the profile, changes
+// are still collected while paused
+func (p *profileStore) setPaused(paused bool) error {
+	p.Paused = paused
+	err := datastore.Put(bucket, p.ID, p)
+	if err != nil {
+		return err
+	}
+
+	if paused {
+		syncer.Pause(p.ID)
+	} else {
+		syncer.Resume(p.ID)
+	}
+	return nil
+}
+
+// syncNow forces a full rescan of the local and remote
+// locations of the profile
+func (p *profileStore) syncNow() error {
+	if !p.Active {
+		return errors.New("Profile is not active.")
+	}
+	if p.Paused {
+		return errors.New("Profile is paused. Resume the profile before syncing.")
+	}
+	profile := syncer.Running(p.ID)
+	if profile == nil {
+		return errors.New("Profile is not currently running.")
+	}
+
+	go func() {
+		err := local.Rescan(profile)
+		if err != nil {
+			log.Error(fmt.Sprintf("Error rescanning local files for profile %s: %s", p.Name, err), local.LogType)
+		}
+	}()
+
+	go remote.Poll(profile)
+
 	return nil
 }
 
 func (p *profileStore) status() (int, string) {
 	count := syncer.ProfileSyncCount(p.ID)
 	if p.Active {
+		if p.Paused {
+			return count, "Paused"
+		}
+		switch _, holding := batches.holding(p.ID); holding {
+		case holdMassChange:
+			return count, "Waiting for confirmation"
+		case holdSuspicious:
+			return count, "Quarantined"
+		}
+		reasons := syncer.PauseReasons(p.ID)
+		for i := range reasons {
+			if reasons[i] == pauseRootUnavailable {
+				return count, "Root unavailable"
+			}
+		}
+		for i := range reasons {
+			if reasons[i] == remote.PauseOffline {
+				return count, "Offline"
+			}
+		}
+		if len(reasons) > 0 {
+			return count, "Paused: " + strings.Join(reasons, ", ")
+		}
 		if count > 0 {
 			return count, "Syncing"
 		}
@@ -235,6 +454,32 @@ func (p *profileStore) delete() error {
 	profile, _ := p.makeProfile()
 	if profile != nil {
 		profile.Stop()
+		if p.RemoteMarked {
+			err := remote.Unmark(profile.Remote.(*remote.File), p.RootMarker)
+			if err != nil {
+				log.Warn(fmt.Sprintf("Error removing the marker file from the remote sync path of profile %s: %s",
+					p.ID, err), remote.LogType)
+			}
+		}
+	}
+
+	failures, err := allFailures(p.ID)
+	if err != nil {
+		return err
+	}
+	for i := range failures {
+		err = failures[i].dismiss()
+		if err != nil {
+			return err
+		}
+	}
+	err = activity.RemoveStats(p.ID)
+	if err != nil {
+		return err
+	}
+	err = removeFingerprints(p.LocalPath)
+	if err != nil {
+		return err
 	}
 	return deleteProfile(p.ID)
 }
@@ -0,0 +1,72 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+)
+
+func TestUpdatedProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-profile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+
+	stored := &profileStore{
+		ID:                   "profile",
+		Name:                 "Documents",
+		Ignore:               []string{"\\.tmp$"},
+		LocalMonitor:         2,
+		RemotePollingSeconds: 300,
+		Paused:               true,
+		massChangeLimits:     massChangeLimits{MassChangeFiles: 500, MassChangePercent: -1},
+	}
+	err = datastore.Put(bucket, stored.ID, stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		update   string
+		expected profileStore
+	}{
+		{"settings left out", `{"id":"profile","name":"Docs","ignore":[]}`, profileStore{
+			ID: "profile", Name: "Docs", Ignore: []string{}, LocalMonitor: 2, RemotePollingSeconds: 300,
+			Paused: true, massChangeLimits: massChangeLimits{MassChangeFiles: 500, MassChangePercent: -1},
+		}},
+		{"settings changed", `{"id":"profile","localMonitor":0,"remotePollingSeconds":60,"massChangeFiles":0}`,
+			profileStore{
+				ID: "profile", Name: "Documents", Ignore: []string{"\\.tmp$"}, RemotePollingSeconds: 60,
+				Paused: true, massChangeLimits: massChangeLimits{MassChangePercent: -1},
+			}},
+		{"new profile", `{"id":"other","name":"Pictures"}`, profileStore{ID: "other", Name: "Pictures"}},
+	}
+
+	for _, test := range tests {
+		p, err := updatedProfile([]byte(test.update))
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if p.ID != test.expected.ID || p.Name != test.expected.Name ||
+			len(p.Ignore) != len(test.expected.Ignore) || p.LocalMonitor != test.expected.LocalMonitor ||
+			p.RemotePollingSeconds != test.expected.RemotePollingSeconds || p.Paused != test.expected.Paused ||
+			p.massChangeLimits != test.expected.massChangeLimits {
+			t.Errorf("%s: updated profile is %+v, expected %+v", test.name, p, test.expected)
+		}
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// Uploads which look like a local file was encrypted, such as by ransomware, are held back.
+// A file looks encrypted when the entropy of its content jumps to that of random data since
+// it was last synced, when a file type which holds text gets content like random data, or
+// when it shows up as a copy of a known file with an extension added.  Deletes of the
+// originals of such copies are held along with them.  If enough suspicious uploads happen
+// within the window, the profile is quarantined: it's paused, and the suspicious changes are
+// held until they are confirmed or rejected.  Otherwise the suspicious changes are let
+// through once the window is over
+const (
+	suspectWindow    = 2 * time.Minute
+	suspectFiles     = 10  // suspicious uploads within the window before the profile is quarantined
+	entropyEncrypted = 7.5 // bits per byte, above which content looks encrypted
+	entropyJump      = 1.5 // rise in bits per byte since the last sync that looks like encryption
+
+	pauseSuspicious = "suspicious changes"
+)
+
+const bucketFingerprint = datastore.BucketFingerprint
+
+// textExtensions are plain text file types, whose content is far from random, so content that
+// looks encrypted is suspicious even when there's no earlier fingerprint to compare against.
+// Binary formats are left out, as many are compressed and look random anyway
+var textExtensions = map[string]bool{
+	".txt": true, ".csv": true, ".log": true, ".md": true, ".rtf": true, ".tex": true,
+	".html": true, ".htm": true, ".xml": true, ".json": true, ".yaml": true, ".yml": true,
+	".ini": true, ".cfg": true, ".conf": true, ".sql": true, ".svg": true,
+	".c": true, ".h": true, ".cpp": true, ".go": true, ".py": true, ".js": true, ".css": true, ".java": true,
+	".sh": true,
+}
+
+// suspectError is returned for changes held back for looking suspicious
+type suspectError struct {
+	reason   string
+	original string // local file the upload looks like an encrypted copy of
+}
+
+func (e *suspectError) Error() string {
+	return "Change held back as suspicious: " + e.reason
+}
+
+// quarantineError is returned for uploads held while the profile is quarantined
+type quarantineError struct {
+	profileID string
+}
+
+func (e *quarantineError) Error() string {
+	return fmt.Sprintf("Profile %s is quarantined for suspicious changes, the change is held until it's confirmed",
+		e.profileID)
+}
+
+// rejectedError is returned for deletes of the original of a rejected suspicious upload
+type rejectedError struct {
+	original string
+}
+
+func (e *rejectedError) Error() string {
+	return fmt.Sprintf("%s has a suspicious copy which was rejected, the delete will be undone", e.original)
+}
+
+// checkWrite returns the check run before each of the profile's writes
+func (p *profileStore) checkWrite(profile *syncer.Profile) func(from, to syncer.Syncer) error {
+	return func(from, to syncer.Syncer) error {
+		return batches.checkWrite(profile, from, to)
+	}
+}
+
+// checkWrite holds back the upload if it looks suspicious, and quarantines the profile if
+// too many suspicious uploads happen within the window
+func (g *batchGuard) checkWrite(p *syncer.Profile, from, to syncer.Syncer) error {
+	if !strings.HasPrefix(to.ID(), p.Remote.ID()) {
+		// downloads are fingerprinted once they finish
+		return nil
+	}
+
+	info, statErr := os.Stat(from.ID())
+	suspect, fp := suspicious(from, to)
+	if fp != nil && statErr == nil {
+		checked.add(from.ID(), info, fp)
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	s := g.state(p.ID())
+	if s.holding == holdSuspicious {
+		return &quarantineError{p.ID()}
+	}
+	if suspect == nil || s.cleared[from.ID()] || time.Now().Before(s.approved) {
+		delete(s.cleared, from.ID())
+		if fp != nil {
+			saveFingerprint(from.ID(), fp)
+		}
+		return nil
+	}
+
+	if suspect.original != "" {
+		s.originals[suspect.original] = true
+	}
+	s.suspectCount++
+	if s.suspectCount < suspectFiles {
+		if s.suspectTimer == nil {
+			s.suspectTimer = time.AfterFunc(suspectWindow, func() {
+				g.releaseSuspects(p)
+			})
+		}
+		return suspect
+	}
+
+	// quarantine
+	if s.suspectTimer != nil {
+		s.suspectTimer.Stop()
+		s.suspectTimer = nil
+	}
+	if s.holding == "" {
+		s.since = time.Now()
+	}
+	s.holding = holdSuspicious
+	s.entries = append(s.entries, s.suspects...)
+	s.suspects = nil
+	s.suspectCount = 0
+	syncer.PauseFor(p.ID(), pauseSuspicious)
+	log.Error(fmt.Sprintf("POSSIBLE RANSOMWARE: %d files of profile %s were rewritten in ways that look like "+
+		"encryption within %s (%s).  The profile has been paused and the uploads quarantined until they are "+
+		"confirmed or rejected.", suspectFiles, p.ID(), suspectWindow, suspect.reason), "Both",
+		log.Fields{Profile: p.ID()})
+	return &quarantineError{p.ID()}
+}
+
+// checkQuarantine holds back deletes and overwrites while the profile is quarantined, as well
+// as deletes of the originals of suspicious uploads.  Deletes of the originals of rejected
+// uploads are undone instead
+func (g *batchGuard) checkQuarantine(p *syncer.Profile, change string, to syncer.Syncer) error {
+	g.Lock()
+	defer g.Unlock()
+	s := g.state(p.ID())
+	if s.holding == holdSuspicious {
+		return &quarantineError{p.ID()}
+	}
+	if change != "delete" || !strings.HasPrefix(to.ID(), p.Remote.ID()) {
+		return nil
+	}
+
+	original := filepath.Join(p.Local.Path(p), to.Path(p))
+	if s.rejected[original] {
+		delete(s.rejected, original)
+		return &rejectedError{original}
+	}
+	if !s.originals[original] {
+		return nil
+	}
+	if s.cleared[original] || s.suspectTimer == nil {
+		// let through along with its suspicious copy
+		delete(s.cleared, original)
+		delete(s.originals, original)
+		return nil
+	}
+	return &suspectError{reason: fmt.Sprintf("%s was deleted after a suspicious copy of it was uploaded", original)}
+}
+
+// holdIfSuspicious holds the change if it was held back for looking suspicious, and undoes
+// deletes of the originals of rejected uploads
+func holdIfSuspicious(p *syncer.Profile, entry *journalEntry, err error) bool {
+	var suspect *suspectError
+	switch e := unwrapError(err).(type) {
+	case *suspectError:
+		suspect = e
+	case *rejectedError:
+		go restoreEntry(p, entry)
+		return true
+	default:
+		return false
+	}
+
+	entry.Original = suspect.original
+	saveErr := entry.save()
+	if saveErr != nil {
+		log.Error(fmt.Sprintf("Error journaling change to %s: %s", entry.Local, saveErr), entry.LogType)
+	}
+
+	batches.Lock()
+	defer batches.Unlock()
+	s := batches.state(p.ID())
+	switch {
+	case s.holding == holdSuspicious:
+		s.entries = append(s.entries, entry)
+	case s.suspectTimer != nil:
+		s.suspects = append(s.suspects, entry)
+	default:
+		// the window ended while this change was running
+		s.cleared[entry.Local] = true
+		go resyncEntries(p, []*journalEntry{entry})
+	}
+	return true
+}
+
+// releaseSuspects lets the suspicious uploads through, if not enough happened within the
+// window to quarantine the profile
+func (g *batchGuard) releaseSuspects(p *syncer.Profile) {
+	g.Lock()
+	defer g.Unlock()
+	s := g.state(p.ID())
+	s.suspectTimer = nil
+	s.suspectCount = 0
+	entries := s.suspects
+	s.suspects = nil
+	if len(entries) == 0 {
+		return
+	}
+
+	for i := range entries {
+		s.cleared[entries[i].Local] = true
+	}
+	log.Info(fmt.Sprintf("Letting through %d suspicious looking uploads for profile %s, as too few happened to "+
+		"quarantine the profile", len(entries), p.ID()), "Both", log.Fields{Profile: p.ID()})
+	go resyncEntries(p, entries)
+}
+
+// suspicious returns why uploading from over to looks like the local file was encrypted, or
+// nil if it doesn't, along with the local file's current fingerprint
+func suspicious(from, to syncer.Syncer) (*suspectError, *local.Fingerprint) {
+	fp, err := local.FingerprintOf(from.ID())
+	if err != nil {
+		return nil, nil
+	}
+	if fp.Entropy < entropyEncrypted {
+		return nil, fp
+	}
+
+	ext := filepath.Ext(from.ID())
+	if to.Exists() {
+		last, err := getFingerprint(from.ID())
+		if err != nil || (last != nil && last.Hash == fp.Hash) {
+			return nil, fp
+		}
+		if last == nil {
+			if textExtensions[strings.ToLower(ext)] {
+				return &suspectError{reason: fmt.Sprintf("the content of %s has an entropy of %.1f bits per "+
+					"byte, which is unlike a %s file", from.ID(), fp.Entropy, ext)}, fp
+			}
+			return nil, fp
+		}
+		if fp.Entropy-last.Entropy >= entropyJump {
+			return &suspectError{reason: fmt.Sprintf("the content entropy of %s rose from %.1f to %.1f bits "+
+				"per byte", from.ID(), last.Entropy, fp.Entropy)}, fp
+		}
+		return nil, fp
+	}
+
+	// a new file named like a known file with an extension added, e.g. report.doc.locked
+	if ext == "" {
+		return nil, fp
+	}
+	original := strings.TrimSuffix(from.ID(), ext)
+	if filepath.Ext(original) == "" {
+		return nil, fp
+	}
+	last, err := getFingerprint(original)
+	if err != nil || last == nil {
+		return nil, fp
+	}
+	return &suspectError{
+		reason:   fmt.Sprintf("%s looks like an encrypted copy of %s", from.ID(), original),
+		original: original,
+	}, fp
+}
+
+// Fingerprints are kept in the datastore keyed by the local file's path
+
+// recordFingerprint updates the fingerprint of the entry's local file once it's in sync, so
+// every synced file has one for later uploads to be checked against
+func recordFingerprint(entry *journalEntry) {
+	info, err := os.Stat(entry.Local)
+	if err != nil || info.IsDir() {
+		// deleted, or a folder
+		checked.take(entry.Local, nil)
+		removeFingerprint(entry.Local)
+		return
+	}
+
+	fp := checked.take(entry.Local, info)
+	if fp == nil {
+		fp, err = local.FingerprintOf(entry.Local)
+		if err != nil {
+			removeFingerprint(entry.Local)
+			return
+		}
+	}
+	last, err := getFingerprint(entry.Local)
+	if err == nil && last != nil && *last == *fp {
+		return
+	}
+	saveFingerprint(entry.Local, fp)
+}
+
+var checked = &checkedFingerprints{
+	files: make(map[string]*checkedFingerprint),
+}
+
+// checkedFingerprints are the fingerprints taken of uploads when they were checked, which are
+// recorded once the upload finishes instead of reading the file again
+type checkedFingerprints struct {
+	sync.Mutex
+	files map[string]*checkedFingerprint
+}
+
+type checkedFingerprint struct {
+	fp      *local.Fingerprint
+	size    int64
+	modTime time.Time
+}
+
+func (c *checkedFingerprints) add(filePath string, info os.FileInfo, fp *local.Fingerprint) {
+	c.Lock()
+	defer c.Unlock()
+	c.files[filePath] = &checkedFingerprint{
+		fp:      fp,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+	}
+}
+
+// take returns the fingerprint taken of the file when it was checked, if the file hasn't
+// changed since, and forgets it
+func (c *checkedFingerprints) take(filePath string, info os.FileInfo) *local.Fingerprint {
+	c.Lock()
+	defer c.Unlock()
+	checked, ok := c.files[filePath]
+	if !ok {
+		return nil
+	}
+	delete(c.files, filePath)
+	if info == nil || checked.size != info.Size() || !checked.modTime.Equal(info.ModTime()) {
+		return nil
+	}
+	return checked.fp
+}
+
+func getFingerprint(filePath string) (*local.Fingerprint, error) {
+	var fp *local.Fingerprint
+	err := datastore.DB().View(func(tx *bolt.Tx) error {
+		value := tx.Bucket([]byte(bucketFingerprint)).Get([]byte(filePath))
+		if value == nil {
+			return nil
+		}
+		fp = &local.Fingerprint{}
+		return json.Unmarshal(value, fp)
+	})
+	return fp, err
+}
+
+func saveFingerprint(filePath string, fp *local.Fingerprint) {
+	value, err := json.Marshal(fp)
+	if err == nil {
+		err = datastore.DB().Update(func(tx *bolt.Tx) error {
+			return tx.Bucket([]byte(bucketFingerprint)).Put([]byte(filePath), value)
+		})
+	}
+	if err != nil {
+		log.Error(fmt.Sprintf("Error saving the fingerprint of %s: %s", filePath, err), local.LogType)
+	}
+}
+
+func removeFingerprint(filePath string) {
+	fp, err := getFingerprint(filePath)
+	if err != nil || fp == nil {
+		return
+	}
+	err = datastore.DB().Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketFingerprint)).Delete([]byte(filePath))
+	})
+	if err != nil {
+		log.Error(fmt.Sprintf("Error removing the fingerprint of %s: %s", filePath, err), local.LogType)
+	}
+}
+
+// removeFingerprints removes the fingerprints of every file under the local root
+func removeFingerprints(root string) error {
+	return datastore.DB().Update(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketFingerprint)).Cursor()
+		root = filepath.Clean(root) + string(filepath.Separator)
+		prefix := []byte(root)
+		for k, _ := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), root); k, _ = c.Seek(prefix) {
+			err := c.Delete()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
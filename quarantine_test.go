@@ -0,0 +1,189 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// destination is an upload destination which only knows whether it exists
+type destination struct {
+	syncer.Syncer
+	exists bool
+}
+
+func (d *destination) Exists() bool {
+	return d.exists
+}
+
+func TestSuspicious(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-quarantine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+
+	random := make([]byte, 64*1024)
+	_, err = rand.Read(random)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog. "), 1000)
+
+	write := func(name string, data []byte) string {
+		filePath := filepath.Join(dir, name)
+		err := ioutil.WriteFile(filePath, data, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return filePath
+	}
+
+	written := write("written.txt", random)
+	current, err := local.FingerprintOf(written)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveFingerprint(written, current)
+	saveFingerprint(write("encrypted.dat", random), &local.Fingerprint{Hash: "before", Entropy: 4.5})
+	saveFingerprint(write("compressed.dat", random), &local.Fingerprint{Hash: "before", Entropy: 7.8})
+	saveFingerprint(filepath.Join(dir, "report.doc"), &local.Fingerprint{Hash: "report", Entropy: 4.5})
+
+	tests := []struct {
+		name     string
+		file     string
+		data     []byte // written to the file first if set
+		exists   bool
+		suspect  bool
+		original string
+	}{
+		{"text", "notes.txt", text, true, false, ""},
+		{"entropy jump", "encrypted.dat", nil, true, true, ""},
+		{"already high entropy", "compressed.dat", nil, true, false, ""},
+		{"unchanged since last sync", "written.txt", nil, true, false, ""},
+		{"random text file without baseline", "new.txt", random, true, true, ""},
+		{"random archive without baseline", "new.zip", random, true, false, ""},
+		{"encrypted copy", "report.doc.locked", random, false, true, filepath.Join(dir, "report.doc")},
+		{"copy of unknown file", "budget.xls.locked", random, false, false, ""},
+		{"new archive", "backup.zip", random, false, false, ""},
+		{"missing", "missing.txt", nil, true, false, ""},
+	}
+
+	for _, test := range tests {
+		filePath := filepath.Join(dir, test.file)
+		if test.data != nil {
+			write(test.file, test.data)
+		}
+		from, err := local.New(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		suspect, _ := suspicious(from, &destination{exists: test.exists})
+		if (suspect != nil) != test.suspect {
+			t.Errorf("%s: suspicious returned %v, expected suspect to be %t", test.name, suspect, test.suspect)
+			continue
+		}
+		if suspect != nil && suspect.original != test.original {
+			t.Errorf("%s: original is %q, expected %q", test.name, suspect.original, test.original)
+		}
+	}
+}
+
+func TestRecordFingerprint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-quarantine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+
+	filePath := filepath.Join(dir, "file.txt")
+	err = ioutil.WriteFile(filePath, []byte("first version"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := local.FingerprintOf(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// stands in for the fingerprint taken when the upload was checked, so it's clear it wasn't read again
+	taken := &local.Fingerprint{Hash: "taken when checked", Entropy: 4}
+
+	tests := []struct {
+		name    string
+		checked *local.Fingerprint
+		change  []byte
+		remove  bool
+		want    *local.Fingerprint
+	}{
+		{"not checked", nil, nil, false, read},
+		{"checked", taken, nil, false, taken},
+		{"changed since checked", taken, []byte("second version, which is longer"), false, nil},
+		{"removed", taken, nil, true, nil},
+	}
+
+	for _, test := range tests {
+		if test.checked != nil {
+			checked.add(filePath, info, test.checked)
+		}
+		if test.change != nil {
+			err = ioutil.WriteFile(filePath, test.change, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if test.remove {
+			err = os.Remove(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		recordFingerprint(&journalEntry{Local: filePath})
+
+		want := test.want
+		if test.change != nil {
+			want, err = local.FingerprintOf(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		got, err := getFingerprint(filePath)
+		if err != nil {
+			t.Fatalf("%s: error getting fingerprint: %s", test.name, err)
+		}
+		if (got == nil) != (want == nil) || (got != nil && *got != *want) {
+			t.Errorf("%s: recorded fingerprint is %v, expected %v", test.name, got, want)
+		}
+		if checked.take(filePath, info) != nil {
+			t.Errorf("%s: checked fingerprint wasn't forgotten", test.name)
+		}
+	}
+}
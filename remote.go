@@ -69,7 +69,7 @@ func remoteClient(input *client) (*fh.Client, error) {
 		return nil, errors.New("Invalid input to retrieve a remote file.  You must provide a password or a token.")
 	}
 
-	c, err := fh.NewFromClient(&http.Client{Timeout: httpTimeout}, *input.URL, *input.User, pass)
+	c, err := fh.NewFromClient(&http.Client{Timeout: httpTimeout, Transport: &remote.Transport{}}, *input.URL, *input.User, pass)
 	if err != nil {
 		return nil, err
 	}
@@ -157,3 +157,10 @@ func tokenPost(w http.ResponseWriter, r *http.Request) {
 		Data:   t,
 	})
 }
+
+func remoteStatsGet(w http.ResponseWriter, r *http.Request) {
+	respondJsend(w, &jsend{
+		Status: statusSuccess,
+		Data:   remote.Stats(),
+	})
+}
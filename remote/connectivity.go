@@ -0,0 +1,206 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// PauseOffline is the reason a profile is paused while its remote instance can't be reached
+const PauseOffline = "offline"
+
+const (
+	healthCheckTimeout = 10 * time.Second
+	// healthCheckCache is how long the result of a health check is reused for, so a burst of
+	// errors doesn't turn into a burst of health checks
+	healthCheckCache = 5 * time.Second
+)
+
+var (
+	connectivity      instanceStates
+	reconnectHandler  ReconnectHandler
+	healthInterval    time.Duration
+	healthTimer       *time.Timer
+	stopHealthCheck   bool
+	healthCheckClient = &http.Client{Timeout: healthCheckTimeout}
+)
+
+func init() {
+	connectivity = instanceStates{
+		instances: make(map[string]*instanceState),
+	}
+}
+
+// ReconnectHandler is called for each running profile of a remote instance
+// when the instance can be reached again after being offline
+type ReconnectHandler func(*syncer.Profile)
+
+type instanceState struct {
+	offline bool
+	since   time.Time // when the instance went offline
+	checked time.Time // last health check
+}
+
+// instanceStates tracks whether or not each remote instance, identified by its root url, can be reached
+type instanceStates struct {
+	sync.Mutex
+	instances map[string]*instanceState
+}
+
+func (s *instanceStates) state(root string) *instanceState {
+	state, ok := s.instances[root]
+	if !ok {
+		state = &instanceState{}
+		s.instances[root] = state
+	}
+	return state
+}
+
+func (s *instanceStates) isOffline(root string) bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.state(root).offline
+}
+
+// recent returns the result of the last health check of the instance, if it was recent enough to reuse
+func (s *instanceStates) recent(root string) (online, ok bool) {
+	s.Lock()
+	defer s.Unlock()
+	state := s.state(root)
+	if time.Since(state.checked) > healthCheckCache {
+		return false, false
+	}
+	return !state.offline, true
+}
+
+// update records the result of a health check, and pauses or resumes the passed in profiles
+// of the instance if it went offline or came back online
+func (s *instanceStates) update(root string, online bool, profiles []*syncer.Profile) {
+	s.Lock()
+	state := s.state(root)
+	state.checked = time.Now()
+	changed := state.offline == online
+	state.offline = !online
+	since := state.since
+	if changed && !online {
+		state.since = state.checked
+	}
+	s.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if !online {
+		log.Warn(fmt.Sprintf("The remote instance %s can't be reached.  Changes will be held until it's back online.",
+			root), LogType)
+		for i := range profiles {
+			syncer.PauseFor(profiles[i].ID(), PauseOffline)
+		}
+		return
+	}
+
+	log.Info(fmt.Sprintf("The remote instance %s is back online after %s.  Syncing held changes.", root,
+		time.Since(since)), LogType)
+	for i := range profiles {
+		syncer.ResumeFrom(profiles[i].ID(), PauseOffline)
+		if reconnectHandler != nil {
+			reconnectHandler(profiles[i])
+		}
+	}
+}
+
+// rootURL returns the root url of the remote instance the profile syncs with
+func rootURL(p *syncer.Profile) string {
+	return p.Remote.(*File).Client().RootURL().String()
+}
+
+// instanceProfiles returns the running profiles which sync with the remote instance
+func instanceProfiles(root string) []*syncer.Profile {
+	var profiles []*syncer.Profile
+	all := syncer.RunningProfiles()
+	for i := range all {
+		if rootURL(all[i]) == root {
+			profiles = append(profiles, all[i])
+		}
+	}
+	return profiles
+}
+
+// healthCheck makes a lightweight request to the root of the remote instance.  Any
+// response at all means the instance can be reached
+func healthCheck(root string) bool {
+	res, err := healthCheckClient.Get(root)
+	if err != nil {
+		return false
+	}
+	res.Body.Close()
+	return true
+}
+
+func checkInstance(root string) bool {
+	online := healthCheck(root)
+	connectivity.update(root, online, instanceProfiles(root))
+	return online
+}
+
+// CheckConnection checks whether or not the remote instance of the passed in profile
+// can be reached.  If the instance has gone offline, all of its profiles are paused until
+// it can be reached again
+func CheckConnection(p *syncer.Profile) bool {
+	root := rootURL(p)
+	if online, ok := connectivity.recent(root); ok {
+		return online
+	}
+	return checkInstance(root)
+}
+
+// IsOffline returns whether or not the remote instance of the passed in profile
+// was offline the last time it was checked
+func IsOffline(p *syncer.Profile) bool {
+	return connectivity.isOffline(rootURL(p))
+}
+
+// StartConnectivityMonitor checks whether or not the remote instance of every running
+// profile can be reached each interval.  The handler is called for each profile
+// of an instance which comes back online
+func StartConnectivityMonitor(interval time.Duration, handler ReconnectHandler) {
+	healthInterval = interval
+	reconnectHandler = handler
+	stopHealthCheck = false
+	healthTimer = time.AfterFunc(healthInterval, checkInstances)
+}
+
+func checkInstances() {
+	roots := make(map[string]struct{})
+	all := syncer.RunningProfiles()
+	for i := range all {
+		roots[rootURL(all[i])] = struct{}{}
+	}
+
+	for root := range roots {
+		if stopHealthCheck {
+			return
+		}
+		checkInstance(root)
+	}
+
+	if !stopHealthCheck {
+		healthTimer = time.AfterFunc(healthInterval, checkInstances)
+	}
+}
+
+func stopConnectivityMonitor() {
+	stopHealthCheck = true
+	if healthTimer != nil {
+		healthTimer.Stop()
+	}
+}
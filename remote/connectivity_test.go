@@ -0,0 +1,96 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+func TestHealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	root := server.URL
+
+	if !healthCheck(root) {
+		t.Errorf("Instance which responded is reported offline")
+	}
+	server.Close()
+	if healthCheck(root) {
+		t.Errorf("Instance which can't be reached is reported online")
+	}
+}
+
+func TestConnectivityTransitions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-connectivity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+
+	root := "https://host"
+	p := &syncer.Profile{
+		Local:  &File{FullURL: "/home/user/docs"},
+		Remote: &File{FullURL: root + "/v1/file/docs/"},
+	}
+	profiles := []*syncer.Profile{p}
+
+	reconnected := 0
+	reconnectHandler = func(*syncer.Profile) { reconnected++ }
+	defer func() { reconnectHandler = nil }()
+
+	tests := []struct {
+		name        string
+		online      bool
+		paused      bool
+		reconnected int
+	}{
+		{"online", true, false, 0},
+		{"goes offline", false, true, 0},
+		{"stays offline", false, true, 0},
+		{"back online", true, false, 1},
+		{"stays online", true, false, 1},
+		{"offline again", false, true, 1},
+		{"online again", true, false, 2},
+	}
+
+	for _, test := range tests {
+		connectivity.update(root, test.online, profiles)
+
+		if offline := connectivity.isOffline(root); offline == test.online {
+			t.Errorf("%s: offline is %t, expected %t", test.name, offline, !test.online)
+		}
+		if online, ok := connectivity.recent(root); !ok || online != test.online {
+			t.Errorf("%s: recent check is %t, %t, expected %t, true", test.name, online, ok, test.online)
+		}
+		paused := false
+		reasons := syncer.PauseReasons(p.ID())
+		for i := range reasons {
+			if reasons[i] == PauseOffline {
+				paused = true
+			}
+		}
+		if paused != test.paused {
+			t.Errorf("%s: paused for being offline is %t, expected %t", test.name, paused, test.paused)
+		}
+		if reconnected != test.reconnected {
+			t.Errorf("%s: reconnected %d times, expected %d", test.name, reconnected, test.reconnected)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+)
+
+// MarkerPrefix starts the name of the file which marks a folder as a remote sync root, so
+// a root which was removed or replaced can be told apart from one whose files were all
+// deleted.  Several clients can sync the same remote folder, so each marker is named
+// with the ID of the profile's marker
+const MarkerPrefix = ".freehold-sync-"
+
+// MarkerError is returned when the remote root or its marker file is missing
+type MarkerError struct {
+	msg string
+}
+
+func (e *MarkerError) Error() string {
+	return e.msg
+}
+
+func markerFile(root *File, id string) (*File, error) {
+	return New(root.Client(), path.Join(root.URL, MarkerPrefix+id))
+}
+
+// Mark writes the marker file with the passed in ID into the remote root, if it isn't there already
+func Mark(root *File, id string) error {
+	marker, err := markerFile(root, id)
+	if err != nil {
+		return err
+	}
+	if marker.Exists() {
+		return nil
+	}
+	return marker.Write(ioutil.NopCloser(strings.NewReader(id)), int64(len(id)), time.Now())
+}
+
+// Unmark removes the marker file with the passed in ID from the remote root
+func Unmark(root *File, id string) error {
+	marker, err := markerFile(root, id)
+	if err != nil {
+		return err
+	}
+	if !marker.Exists() {
+		return nil
+	}
+	return marker.Delete()
+}
+
+// CheckMarker returns a MarkerError if the remote root doesn't exist, or its marker file for
+// the passed in ID is missing
+func CheckMarker(root *File, id string) error {
+	marker, err := markerFile(root, id)
+	if err != nil {
+		return err
+	}
+	if marker.Exists() {
+		return nil
+	}
+
+	current, err := New(root.Client(), root.URL)
+	if err != nil {
+		return err
+	}
+	if !current.Exists() {
+		return &MarkerError{fmt.Sprintf("The remote sync root %s is missing", root.ID())}
+	}
+	return &MarkerError{fmt.Sprintf("The remote sync root %s is missing its %s marker file", root.ID(),
+		MarkerPrefix+id)}
+}
@@ -5,13 +5,14 @@
 package remote
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
-	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/boltdb/bolt"
+
 	fh "bitbucket.org/tshannon/freehold-client"
 	"bitbucket.org/tshannon/freehold-sync/datastore"
 	"bitbucket.org/tshannon/freehold-sync/log"
@@ -30,13 +31,16 @@ var (
 	stopped       chan int
 	ignore        ignoreFiles //File changes to ignore because they are from this process
 	pollInterval  time.Duration
+	pollWorkers   int
 	pollTimer     *time.Timer
 	stopPoll      bool
 )
 
 func init() {
 	watching = profileFiles{
-		files: make(map[string][]*syncer.Profile),
+		files:        make(map[string][]*syncer.Profile),
+		schedule:     make(map[string]*pollSchedule),
+		fingerprints: make(map[string]fingerprint),
 	}
 	stopped = make(chan int)
 	ignore = ignoreFiles{
@@ -46,7 +50,9 @@ func init() {
 
 type profileFiles struct {
 	sync.RWMutex
-	files map[string][]*syncer.Profile
+	files        map[string][]*syncer.Profile
+	schedule     map[string]*pollSchedule
+	fingerprints map[string]fingerprint // folder fingerprints as last seen in their parent's listing
 }
 
 func (p *profileFiles) add(profile *syncer.Profile, file *File) {
@@ -74,6 +80,7 @@ func (p *profileFiles) add(profile *syncer.Profile, file *File) {
 	defer p.Unlock()
 
 	p.files[file.ID()] = []*syncer.Profile{profile}
+	p.schedule[file.ID()] = newPollSchedule(p.files[file.ID()], fingerprintOf(file))
 
 	return
 }
@@ -106,70 +113,184 @@ func (p *profileFiles) profiles(f *File) []*syncer.Profile {
 func (p *profileFiles) remove(profile *syncer.Profile, file *File) {
 	//If profile is nil, remove all from file, and remove watch
 	// if last profile is removed, remove watch
+	p.Lock()
+	defer p.Unlock()
+
+	profiles, ok := p.files[file.ID()]
+	if !ok {
+		// not currently watching file
+		return
+	}
+
+	if profile == nil {
+		p.unwatch(file.ID())
+		return
+	}
 
+	for i := range profiles {
+		if profiles[i].ID() == profile.ID() {
+			//remove profile
+			profiles = append(profiles[:i], profiles[i+1:]...)
+			break
+		}
+	}
+	if len(profiles) == 0 {
+		p.unwatch(file.ID())
+		//remove from DS if exists
+		removeSnapshot(file.ID())
+
+		return
+	}
+	p.files[file.ID()] = profiles
+}
+
+// unwatch stops polling the folder, must be called under lock
+func (p *profileFiles) unwatch(dir string) {
+	delete(p.files, dir)
+	delete(p.schedule, dir)
+	delete(p.fingerprints, dir)
+}
+
+// dueDirs returns the IDs of the watched folders which are due to be polled
+func (p *profileFiles) dueDirs(now time.Time) []string {
 	p.RLock()
-	if profiles, ok := p.files[file.ID()]; ok {
-		p.RUnlock()
-		p.Lock()
-		defer p.Unlock()
+	defer p.RUnlock()
 
-		if profile == nil {
-			delete(p.files, file.ID())
-			return
+	var due []string
+	for k, s := range p.schedule {
+		if !s.next.After(now) {
+			due = append(due, k)
 		}
+	}
+	return due
+}
 
-		for i := range profiles {
-			if profiles[i].ID() == profile.ID() {
-				//remove profile
-				profiles = append(profiles[:i], profiles[i+1:]...)
+// profileDirs returns the IDs of all the folders watched by the passed in profile
+func (p *profileFiles) profileDirs(profile *syncer.Profile) []string {
+	p.RLock()
+	defer p.RUnlock()
+
+	var dirs []string
+	for k, v := range p.files {
+		for i := range v {
+			if v[i].ID() == profile.ID() {
+				dirs = append(dirs, k)
 				break
 			}
 		}
-		if len(profiles) == 0 {
-			delete(p.files, file.ID())
-			//remove from DS if exists
-			datastore.Delete(bucket, file.ID())
+	}
+	return dirs
+}
 
-			return
+// prune skips polling any of the passed in folders which look unchanged based on their
+// fingerprint in their parent's last listing, and returns the folders which still need polling
+func (p *profileFiles) prune(dirs []string) []string {
+	p.Lock()
+	defer p.Unlock()
+
+	poll := make([]string, 0, len(dirs))
+	for i := range dirs {
+		s, ok := p.schedule[dirs[i]]
+		if !ok {
+			continue
 		}
+		current, known := p.fingerprints[dirs[i]]
+		if s.unchanged(current, known) {
+			s.skipped++
+			s.update(false, p.files[dirs[i]])
+			continue
+		}
+		poll = append(poll, dirs[i])
+	}
+	return poll
+}
+
+// seen records the fingerprint of a child folder from its parent's listing.  If the
+// child is watched and has changed since it was last listed, it's polled right away
+func (p *profileFiles) seen(dir string, current fingerprint) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.fingerprints[dir] = current
+	if s, ok := p.schedule[dir]; ok && !s.listed.equal(current) {
+		s.next = time.Now()
+	}
+}
+
+// polled schedules the next poll of the folder based on whether or not it changed,
+// and records the fingerprint of the folder at the time it was listed
+func (p *profileFiles) polled(dir string, changed bool, listed fingerprint) {
+	p.Lock()
+	defer p.Unlock()
+
+	if s, ok := p.schedule[dir]; ok {
+		s.listed = listed
+		s.skipped = 0
+		s.update(changed, p.files[dir])
 	}
-	p.RUnlock()
-	// not currently watching file
-	return
 }
 
-func (p *profileFiles) dirWatchList() ([]*File, error) {
+// nextPoll returns how long to wait until the next folder is due to be polled.
+// Waits no longer than the default poll interval, so newly watched folders are picked up
+func (p *profileFiles) nextPoll() time.Duration {
 	p.RLock()
 	defer p.RUnlock()
 
-	result := make([]*File, len(p.files), len(p.files))
-	i := 0
-	for k, v := range p.files {
-		// All profiles watching this folder will share the same client root
-		uri, err := url.Parse(k)
-		if err != nil {
-			return nil, fmt.Errorf("Error parsing file watch url: %v", err)
+	wait := pollInterval
+	now := time.Now()
+	for _, s := range p.schedule {
+		if s.next.Sub(now) < wait {
+			wait = s.next.Sub(now)
 		}
+	}
+	if wait < minPollWait {
+		wait = minPollWait
+	}
+	return wait
+}
 
-		result[i], err = New(v[0].Remote.(*File).Client(), uri.Path)
-		if err != nil {
-			return nil, fmt.Errorf("Error building remote dir watch list: %v", err)
-		}
-		i++
+// dirFile builds the remote folder for the passed in watched folder ID, returns nil
+// if the folder is no longer being watched, or its remote instance is offline
+func (p *profileFiles) dirFile(dir string) (*File, error) {
+	p.RLock()
+	profiles := p.files[dir]
+	p.RUnlock()
+	if len(profiles) == 0 || IsOffline(profiles[0]) {
+		return nil, nil
+	}
+
+	// All profiles watching this folder will share the same client root
+	uri, err := url.Parse(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing file watch url: %v", err)
 	}
 
-	return result, nil
+	return New(profiles[0].Remote.(*File).Client(), uri.Path)
 }
 
 // ChangeHandler is the function called when a change occurs in a monitored folder
 type ChangeHandler func(*syncer.Profile, syncer.Syncer)
 
+// PollSettings determine how often and how many remote folders are polled for changes
+type PollSettings struct {
+	Interval    time.Duration // how often to poll a folder that has recently changed
+	MaxInterval time.Duration // quiet folders back off until they are polled this often
+	FullCycles  int           // number of times a folder that looks unchanged can be skipped before it's listed, 0 never skips
+	Workers     int           // max number of folders to poll at once
+}
+
 // StartWatcher Starts remote file system monitoring
-func StartWatcher(handler ChangeHandler, interval time.Duration) error {
+func StartWatcher(handler ChangeHandler, settings PollSettings) error {
 	changeHandler = handler
-	pollInterval = interval
+	pollInterval = settings.Interval
+	maxPollInterval = settings.MaxInterval
+	fullPollCycles = settings.FullCycles
+	pollWorkers = settings.Workers
+	if pollWorkers < 1 {
+		pollWorkers = 1
+	}
 
-	// Loop every pollInterval
+	// Loop until the next folder is due
 	// record what the current folder looks like
 	// call changeHandler for any file that changed
 	// set deleted boolean if file used to exist and no longer does
@@ -179,34 +300,95 @@ func StartWatcher(handler ChangeHandler, interval time.Duration) error {
 }
 
 func watchDirs() {
-	var wg sync.WaitGroup
-	watchList, err := watching.dirWatchList()
-	if err != nil {
-		log.New(fmt.Sprintf("Error getting watch list: %s", err.Error()), LogType)
+	start := time.Now()
+	due := watching.dueDirs(start)
+	if len(due) > 0 {
+		poll := watching.prune(due)
+		result := pollDirs(poll)
+		result.skipped = len(due) - len(poll)
+		stats.record(start, time.Since(start), result)
 	}
-	for i := range watchList {
+
+	if !stopPoll {
+		pollTimer = time.AfterFunc(watching.nextPoll(), watchDirs)
+	}
+}
+
+// pollDirs checks each of the passed in folders for differences and calls the change handler
+// for every profile watching the folder.  No more than pollWorkers folders are polled at once
+func pollDirs(dirs []string) *pollResult {
+	result := &pollResult{}
+	work := make(chan string)
+	var wg sync.WaitGroup
+
+	for i := 0; i < pollWorkers && i < len(dirs); i++ {
 		wg.Add(1)
-		go func(watchFile *File) {
+		go func() {
 			defer wg.Done()
-			diff, err := watchFile.differences()
-			profiles := watching.profiles(watchFile)
-			if err != nil {
-				log.New(fmt.Sprintf("Error getting differences for %s: %s", watchFile.ID(), err.Error()), LogType)
-			}
-			for d := range diff {
-				for p := range profiles {
-					changeHandler(profiles[p], diff[d])
-				}
-
+			for dir := range work {
+				changed, err := pollDir(dir)
+				result.add(changed, err)
 			}
+		}()
+	}
 
-		}(watchList[i])
+	for i := range dirs {
+		work <- dirs[i]
 	}
+	close(work)
 	wg.Wait()
 
-	if !stopPoll {
-		pollTimer = time.AfterFunc(pollInterval, watchDirs)
+	return result
+}
+
+// pollDir checks a single folder for differences
+func pollDir(dir string) (bool, error) {
+	watchFile, err := watching.dirFile(dir)
+	if err != nil {
+		if !connectionLost(dir) {
+			log.Error(fmt.Sprintf("Error building remote dir watch list: %v", err), LogType)
+		}
+		return false, err
+	}
+	if watchFile == nil {
+		return false, nil
+	}
+
+	diff, changed, err := watchFile.differences()
+	profiles := watching.profiles(watchFile)
+	if err != nil && !connectionLost(dir) {
+		log.Error(fmt.Sprintf("Error getting differences for %s: %s", watchFile.ID(), err.Error()), LogType)
+	}
+	watching.polled(watchFile.ID(), changed, fingerprintOf(watchFile))
+
+	for d := range diff {
+		for p := range profiles {
+			// differences are already recorded in the DS, so a paused profile
+			// shouldn't hold up polling for everyone else
+			profiles[p].QueueEvent(diff[d], syncer.EventHandler(changeHandler))
+		}
 	}
+	return changed, err
+}
+
+// connectionLost checks whether or not a polling error was caused by the folder's
+// remote instance going offline
+func connectionLost(dir string) bool {
+	watching.RLock()
+	profiles := watching.files[dir]
+	watching.RUnlock()
+	if len(profiles) == 0 {
+		return false
+	}
+	return !CheckConnection(profiles[0])
+}
+
+// Poll immediately checks all of the remote folders watched by the passed in
+// profile for changes
+func Poll(p *syncer.Profile) {
+	start := time.Now()
+	result := pollDirs(watching.profileDirs(p))
+	stats.record(start, time.Since(start), result)
 }
 
 // ResumeWatcher resumes remote monitoring
@@ -223,117 +405,133 @@ func PauseWatcher() {
 	}
 }
 
-// StopWatcher stops the local file system monitoring
+// StopWatcher stops the remote monitoring
 func StopWatcher() {
 	//stop polling
 	stopPoll = true
 	if pollTimer != nil {
 		pollTimer.Stop()
 	}
+	stopConnectivityMonitor()
 }
 
 // Returns the differences between the local record of the folder and
 // the current remote view of the folder.  Sets deleted if file used
-// to exist
-func (f *File) differences() ([]syncer.Syncer, error) {
-	var diff []syncer.Syncer
+// to exist.  Child folders are always returned to make sure they are monitored,
+// changed is only true if something in the folder actually changed
+func (f *File) differences() (diff []syncer.Syncer, changed bool, err error) {
 	if !f.IsDir() {
-		return nil, nil
+		return nil, false, nil
 	}
 
 	remFiles, err := f.Children()
 	if err != nil && !fh.IsNotFound(err) {
-		return nil, err
+		return nil, false, err
 	}
 
 	if fh.IsNotFound(err) {
 		//clean up monitor and update ds
 		err = f.StopMonitor(nil)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		return nil, nil
-	}
-
-	var dsFiles []*File
-
-	err = datastore.Get(bucket, f.ID(), &dsFiles)
-	if err != nil && err != datastore.ErrNotFound {
-		return nil, fmt.Errorf("Error reading remote DS file list for %s: Error: %s", f.ID(), err.Error())
+		return nil, true, nil
 	}
 
-	for i := range dsFiles {
-		if ignore.has(dsFiles[i].ID()) {
-			continue
-		}
-		found := false
-		for j := range remFiles {
-			if remFiles[j].ID() == dsFiles[i].ID() {
-				found = true
-				//Dirs are always marked as different
-				// to ensure they are being monitored see syncer.Profile.Sync
-				if !remFiles[j].Modified().Equal(dsFiles[i].Modified()) || remFiles[j].IsDir() {
-					diff = append(diff, remFiles[j])
-				}
-			}
-		}
-		if !found {
-			//Exists in DS but not remote
-			// file was deleted
-			dsFiles[i].deleted = true
-			diff = append(diff, dsFiles[i])
-			dsFiles[i].StopMonitor(nil)
+	remMap := make(map[string]*File, len(remFiles))
+	for i := range remFiles {
+		remMap[remFiles[i].ID()] = remFiles[i]
+		if remFiles[i].IsDir() {
+			watching.seen(remFiles[i].ID(), fingerprintOf(remFiles[i]))
 		}
 	}
 
-	for i := range remFiles {
-		if ignore.has(remFiles[i].ID()) {
-			continue
+	var deleted []*File
+
+	// compare and update the stored view of the folder in one transaction, so concurrent
+	// polls and deletes of the same folder can't overwrite each other
+	err = datastore.DB().Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket([]byte(bucket)).CreateBucketIfNotExists([]byte(f.ID()))
+		if err != nil {
+			return err
 		}
-		found := false
-		for j := range dsFiles {
-			if remFiles[i].ID() == dsFiles[j].ID() {
-				found = true
+
+		dsMap := make(map[string]*File, len(remFiles))
+		err = b.ForEach(func(k, v []byte) error {
+			dsFile := &File{}
+			err := json.Unmarshal(v, dsFile)
+			if err != nil {
+				return err
 			}
+			dsMap[string(k)] = dsFile
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("Error reading remote DS file list for %s: Error: %s", f.ID(), err.Error())
 		}
-		if !found {
-			//Exists in Remote, but not DS
-			// file is new
 
-			diff = append(diff, remFiles[i])
-		}
-	}
+		for id, dsFile := range dsMap {
+			rem, found := remMap[id]
+			if !found {
+				//Exists in DS but not remote
+				// file was deleted
+				err = b.Delete([]byte(id))
+				if err != nil {
+					return err
+				}
+				if ignore.has(id) {
+					continue
+				}
+				dsFile.deleted = true
+				diff = append(diff, dsFile)
+				deleted = append(deleted, dsFile)
+				changed = true
+				continue
+			}
 
-	// insert current view of remote site into DS
-	err = datastore.Put(bucket, f.ID(), remFiles)
-	if err != nil {
-		return nil, err
-	}
+			if ignore.has(id) {
+				continue
+			}
 
-	return diff, nil
-}
+			//Dirs are always marked as different
+			// to ensure they are being monitored see syncer.Profile.Sync
+			if !rem.ModifiedTime.Equal(dsFile.ModifiedTime) {
+				diff = append(diff, rem)
+				changed = true
+			} else if rem.IsDir() {
+				diff = append(diff, rem)
+			}
+		}
 
-func deleteRemoteFileFromDS(fileID string) error {
-	var dsFiles []*File
-	parent := filepath.Dir(strings.TrimRight(fileID, "/"))
+		for id, rem := range remMap {
+			dsFile, found := dsMap[id]
+			if found && dsFile.ModifiedTime.Equal(rem.ModifiedTime) {
+				continue
+			}
+			// insert current view of the remote file into DS
+			err = putSnapshotEntry(b, rem)
+			if err != nil {
+				return err
+			}
 
-	err := datastore.Get(bucket, parent, &dsFiles)
-	if err == datastore.ErrNotFound {
-		return nil //nothing to delete
-	}
+			if !found && !ignore.has(id) {
+				//Exists in Remote, but not DS
+				// file is new
+				diff = append(diff, rem)
+				changed = true
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return err
+		return nil, false, err
 	}
 
-	for i := range dsFiles {
-		if dsFiles[i].ID() == fileID {
-			//Remove file from list
-			dsFiles = append(dsFiles[:i], dsFiles[i+1:]...)
-			break
-		}
+	for i := range deleted {
+		deleted[i].StopMonitor(nil)
 	}
 
-	return datastore.Put(bucket, parent, dsFiles)
+	return diff, changed, nil
 }
 
 type ignoreFiles struct {
@@ -15,7 +15,6 @@ import (
 	"time"
 
 	fh "bitbucket.org/tshannon/freehold-client"
-	"bitbucket.org/tshannon/freehold-sync/datastore"
 	"bitbucket.org/tshannon/freehold-sync/syncer"
 )
 
@@ -55,7 +54,7 @@ func New(client *fh.Client, filePath string) (*File, error) {
 		return f, nil
 	}
 	if err != nil {
-		return nil, err
+		return nil, statusError(err, filePath)
 	}
 
 	f = newFromFile(client, file)
@@ -130,7 +129,7 @@ func (f *File) Children() ([]*File, error) {
 	}
 	children, err := f.file.Children()
 	if err != nil {
-		return nil, err
+		return nil, statusError(err, f.file.URL)
 	}
 	syncers := make([]*File, len(children))
 
@@ -148,15 +147,19 @@ func (f *File) Open() (io.ReadCloser, error) {
 // Read reads the data out of the remote file
 func (f *File) Read(p []byte) (n int, err error) {
 	if !f.exists {
-		return 0, fmt.Errorf("Can't read file %s , because it doesn't exist.")
+		return 0, fmt.Errorf("Can't read file %s , because it doesn't exist.", f.ID())
 	}
-	return f.file.Read(p)
+	n, err = f.file.Read(p)
+	if err != nil && err != io.EOF {
+		err = statusError(err, f.file.URL)
+	}
+	return n, err
 }
 
 // Close closes an open file reader
 func (f *File) Close() error {
 	if !f.exists {
-		return fmt.Errorf("Can't close file %s , because it doesn't exist.")
+		return fmt.Errorf("Can't close file %s , because it doesn't exist.", f.ID())
 	}
 	return f.file.Close()
 }
@@ -174,7 +177,7 @@ func (f *File) Write(r io.ReadCloser, size int64, modTime time.Time) error {
 	if f.exists {
 		err = f.file.Delete()
 		if err != nil && !fh.IsNotFound(err) {
-			return err
+			return statusError(err, f.file.URL)
 		}
 	}
 	dest := &fh.File{
@@ -187,7 +190,7 @@ func (f *File) Write(r io.ReadCloser, size int64, modTime time.Time) error {
 
 	newFile, err := f.client.UploadFromReader(f.Name, r, size, modTime, dest)
 	if err != nil {
-		return err
+		return statusError(err, f.URL)
 	}
 
 	f.file = newFile
@@ -235,7 +238,7 @@ func (f *File) Delete() error {
 
 	err := f.file.Delete()
 	if err != nil && !fh.IsNotFound(err) {
-		return err
+		return statusError(err, f.file.URL)
 	}
 	return nil
 }
@@ -258,7 +261,7 @@ func (f *File) Rename() error {
 
 	newName += time.Now().Format(time.Stamp) + ext
 
-	return f.file.Move(newName)
+	return statusError(f.file.Move(newName), f.file.URL)
 }
 
 // Size returns the size of the file
@@ -288,7 +291,7 @@ func (f *File) CreateDir() (syncer.Syncer, error) {
 	err := f.client.NewFolder(f.URL)
 	if err != nil {
 		if !strings.Contains(err.Error(), "Folder already exists") {
-			return nil, err
+			return nil, statusError(err, f.URL)
 		}
 	}
 
@@ -308,7 +311,7 @@ func (f *File) StartMonitor(p *syncer.Profile) error {
 	// Start watching, and check for current differences
 	// if folder hasn't been watched yet, then all
 	// files will be checked
-	diff, err := f.differences()
+	diff, _, err := f.differences()
 	if err != nil {
 		return err
 	}
@@ -317,9 +320,7 @@ func (f *File) StartMonitor(p *syncer.Profile) error {
 	// child folders are monitored recursively and all
 	// files are in sync
 	for i := range diff {
-		go func(s syncer.Syncer) {
-			changeHandler(p, s)
-		}(diff[i])
+		p.QueueEvent(diff[i], syncer.EventHandler(changeHandler))
 	}
 
 	watching.add(p, f)
@@ -349,35 +350,6 @@ func (f *File) stopWatcherRecursive(p *syncer.Profile) error {
 	}
 	watching.remove(p, f)
 	deleteRemoteFileFromDS(f.ID())
-	f.removeFromRemoteDS()
+	removeSnapshot(f.ID())
 	return nil
 }
-
-func (f *File) removeFromRemoteDS() error {
-	err := datastore.Delete(bucket, f.ID())
-	if err == datastore.ErrNotFound {
-		return nil
-	}
-	return err
-}
-
-func (f *File) inRemoteDS() (bool, error) {
-	dsFiles := make([]*File, 0, 1)
-	parent := path.Dir(strings.TrimRight(f.ID(), "/"))
-
-	err := datastore.Get(bucket, parent, &dsFiles)
-	if err == datastore.ErrNotFound {
-		return false, nil
-	}
-	if err != nil {
-		return false, err
-	}
-
-	for i := range dsFiles {
-		if dsFiles[i].ID() == f.ID() {
-			return true, nil
-		}
-	}
-
-	return false, nil
-}
@@ -0,0 +1,127 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"math/rand"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// pollJitter is the fraction of a folder's polling interval that is randomly added or
+// removed, so many clients don't all poll the server in lockstep
+const pollJitter = 0.2
+
+// minPollWait is the shortest time the poller will wait before checking for due folders
+const minPollWait = time.Second
+
+var (
+	maxPollInterval time.Duration
+	fullPollCycles  int                                               // how many times a folder can be skipped before it must be listed again
+	jitterRand      = rand.New(rand.NewSource(time.Now().UnixNano())) // only used under the watching lock
+)
+
+// pollSchedule determines when a watched folder will be polled next.  Folders
+// with recent changes are polled at the base interval, quiet folders back off
+// until they reach the max interval
+type pollSchedule struct {
+	interval time.Duration
+	next     time.Time
+	listed   fingerprint // fingerprint of the folder the last time its children were listed
+	skipped  int         // number of polls skipped in a row because the folder looked unchanged
+}
+
+func newPollSchedule(profiles []*syncer.Profile, listed fingerprint) *pollSchedule {
+	base, _ := pollIntervals(profiles)
+	return &pollSchedule{
+		interval: base,
+		next:     time.Now().Add(jitter(base)),
+		listed:   listed,
+	}
+}
+
+// unchanged returns whether or not the folder's current fingerprint shows it hasn't
+// changed since its children were last listed, and it isn't due for a full listing
+func (s *pollSchedule) unchanged(current fingerprint, known bool) bool {
+	if !known || s.listed.isZero() || fullPollCycles <= 0 {
+		return false
+	}
+	if s.skipped >= fullPollCycles {
+		return false
+	}
+	return s.listed.equal(current)
+}
+
+// update schedules the next poll based on whether or not the folder changed
+// on the last poll
+func (s *pollSchedule) update(changed bool, profiles []*syncer.Profile) {
+	base, max := pollIntervals(profiles)
+
+	if changed || s.interval < base {
+		s.interval = base
+	} else {
+		s.interval *= 2
+		if s.interval > max {
+			s.interval = max
+		}
+	}
+	s.next = time.Now().Add(jitter(s.interval))
+}
+
+// pollIntervals returns the base and max polling intervals for a folder watched
+// by the passed in profiles.  The folder is polled as often as the most frequent profile
+// wants it to be
+func pollIntervals(profiles []*syncer.Profile) (base, max time.Duration) {
+	for i := range profiles {
+		interval := pollInterval
+		if profiles[i].RemotePollInterval > 0 {
+			interval = profiles[i].RemotePollInterval
+		}
+		if base == 0 || interval < base {
+			base = interval
+		}
+	}
+	if base == 0 {
+		base = pollInterval
+	}
+
+	max = maxPollInterval
+	if max < base {
+		max = base
+	}
+	return base, max
+}
+
+// fingerprint is what freehold exposes about a folder which changes when its
+// contents change.  A folder's modified time changes when its entries are added
+// or removed, deeper changes may only be caught on a full listing
+type fingerprint struct {
+	modified time.Time
+	size     int64
+}
+
+func fingerprintOf(f *File) fingerprint {
+	return fingerprint{
+		modified: f.ModifiedTime,
+		size:     f.Size(),
+	}
+}
+
+func (fp fingerprint) equal(other fingerprint) bool {
+	return fp.modified.Equal(other.modified) && fp.size == other.size
+}
+
+func (fp fingerprint) isZero() bool {
+	return fp.modified.IsZero() && fp.size == 0
+}
+
+func jitter(d time.Duration) time.Duration {
+	spread := int64(float64(d) * pollJitter)
+	if spread <= 0 {
+		return d
+	}
+	return d - time.Duration(spread) + time.Duration(jitterRand.Int63n(2*spread))
+}
@@ -0,0 +1,147 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/boltdb/bolt"
+
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// The last seen view of each watched remote folder is stored in its own bucket
+// nested in the remote bucket, keyed by the folder's ID.  Each child of the folder
+// is stored as a separate entry keyed by the child's ID, so single entries can be
+// updated without rewriting the whole folder
+
+// parentID returns the ID of the folder containing the passed in remote file ID
+func parentID(id string) string {
+	trimmed := strings.TrimSuffix(id, "/")
+	i := strings.LastIndex(trimmed, "/")
+	if i < 0 {
+		return ""
+	}
+	return trimmed[:i+1]
+}
+
+// snapshotEntry is how a child is stored in its folder's last seen view
+type snapshotEntry struct {
+	*File
+	Size  int64 `json:"size"`
+	IsDir bool  `json:"isDir"`
+}
+
+func putSnapshotEntry(b *bolt.Bucket, f *File) error {
+	value, err := json.Marshal(&snapshotEntry{
+		File:  f,
+		Size:  f.Size(),
+		IsDir: f.IsDir(),
+	})
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(f.ID()), value)
+}
+
+// Totals returns the number of files and their total size under the remote starting
+// point of the profile, as of the last time each folder was polled
+func Totals(p *syncer.Profile) (files int, bytes int64, err error) {
+	return totals(p.Remote.ID())
+}
+
+// FolderTotals returns the number of files and their total size under the passed in
+// watched folder, as of the last time each folder was polled
+func FolderTotals(dirID string) (files int, bytes int64, err error) {
+	if !strings.HasSuffix(dirID, "/") {
+		dirID += "/"
+	}
+	return totals(dirID)
+}
+
+func totals(root string) (files int, bytes int64, err error) {
+	err = datastore.DB().View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucket)).Cursor()
+		for k, v := c.Seek([]byte(root)); k != nil && strings.HasPrefix(string(k), root); k, v = c.Next() {
+			if v != nil {
+				continue
+			}
+			err := c.Bucket().Bucket(k).ForEach(func(_, value []byte) error {
+				entry := &snapshotEntry{}
+				err := json.Unmarshal(value, entry)
+				if err != nil {
+					return err
+				}
+				if !entry.IsDir {
+					files++
+					bytes += entry.Size
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return files, bytes, err
+}
+
+// inRemoteDS returns whether or not the file is in its parent folder's
+// last seen view of the remote site
+func (f *File) inRemoteDS() (bool, error) {
+	in := false
+	err := datastore.DB().View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket)).Bucket([]byte(parentID(f.ID())))
+		if b == nil {
+			return nil
+		}
+		in = b.Get([]byte(f.ID())) != nil
+		return nil
+	})
+	return in, err
+}
+
+// deleteRemoteFileFromDS removes the file from its parent folder's last seen view
+func deleteRemoteFileFromDS(fileID string) error {
+	return datastore.DB().Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket)).Bucket([]byte(parentID(fileID)))
+		if b == nil {
+			return nil //nothing to delete
+		}
+		return b.Delete([]byte(fileID))
+	})
+}
+
+// removeSnapshot removes the last seen view of the folder, as well as the
+// views of any folders below it
+func removeSnapshot(dirID string) error {
+	return datastore.DB().Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		var dirs [][]byte
+
+		if strings.HasSuffix(dirID, "/") {
+			prefix := []byte(dirID)
+			c := b.Cursor()
+			for k, v := c.Seek(prefix); k != nil && strings.HasPrefix(string(k), dirID); k, v = c.Next() {
+				if v == nil {
+					dirs = append(dirs, append([]byte(nil), k...))
+				}
+			}
+		} else if b.Bucket([]byte(dirID)) != nil {
+			dirs = append(dirs, []byte(dirID))
+		}
+
+		for i := range dirs {
+			err := b.DeleteBucket(dirs[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/boltdb/bolt"
+
+	fh "bitbucket.org/tshannon/freehold-client"
+	"bitbucket.org/tshannon/freehold-sync/datastore"
+)
+
+func TestParentID(t *testing.T) {
+	tests := []struct {
+		id     string
+		parent string
+	}{
+		{"https://host/v1/file/docs/a.txt", "https://host/v1/file/docs/"},
+		{"https://host/v1/file/docs/sub/", "https://host/v1/file/docs/"},
+		{"https://host/v1/file/docs/sub/b.txt", "https://host/v1/file/docs/sub/"},
+		{"file", ""},
+	}
+
+	for _, test := range tests {
+		if parent := parentID(test.id); parent != test.parent {
+			t.Errorf("Parent of %s is %s, expected %s", test.id, parent, test.parent)
+		}
+	}
+}
+
+func snapshotFile(id string, size int64, isDir bool) *File {
+	return &File{
+		FullURL: id,
+		exists:  true,
+		file:    &fh.File{Property: fh.Property{Size: size, IsDir: isDir}},
+	}
+}
+
+func TestSnapshotTotals(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = datastore.Open(filepath.Join(dir, "sync.ds"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer datastore.Close()
+
+	folders := map[string][]*File{
+		"https://host/v1/file/docs/": {
+			snapshotFile("https://host/v1/file/docs/a.txt", 10, false),
+			snapshotFile("https://host/v1/file/docs/b.txt", 20, false),
+			snapshotFile("https://host/v1/file/docs/sub/", 0, true),
+		},
+		"https://host/v1/file/docs/sub/": {
+			snapshotFile("https://host/v1/file/docs/sub/c.txt", 30, false),
+		},
+		"https://host/v1/file/docs/subway/": {
+			snapshotFile("https://host/v1/file/docs/subway/d.txt", 40, false),
+		},
+		"https://host/v1/file/other/": {
+			snapshotFile("https://host/v1/file/other/e.txt", 50, false),
+		},
+	}
+	err = datastore.DB().Update(func(tx *bolt.Tx) error {
+		for id, children := range folders {
+			b, err := tx.Bucket([]byte(bucket)).CreateBucket([]byte(id))
+			if err != nil {
+				return err
+			}
+			for i := range children {
+				err = putSnapshotEntry(b, children[i])
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		dir   string
+		files int
+		bytes int64
+	}{
+		{"folder and below", "https://host/v1/file/docs/", 4, 100},
+		{"sub folder", "https://host/v1/file/docs/sub/", 1, 30},
+		{"without trailing slash", "https://host/v1/file/docs/sub", 1, 30},
+		{"unwatched", "https://host/v1/file/missing/", 0, 0},
+	}
+
+	for _, test := range tests {
+		files, bytes, err := FolderTotals(test.dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if files != test.files || bytes != test.bytes {
+			t.Errorf("%s: totals are %d files and %d bytes, expected %d files and %d bytes", test.name, files,
+				bytes, test.files, test.bytes)
+		}
+	}
+
+	err = deleteRemoteFileFromDS("https://host/v1/file/docs/a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = removeSnapshot("https://host/v1/file/docs/sub/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, bytes, err := FolderTotals("https://host/v1/file/docs/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files != 2 || bytes != 60 {
+		t.Errorf("Totals after removing entries are %d files and %d bytes, expected 2 files and 60 bytes", files,
+			bytes)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"sync"
+	"time"
+)
+
+var stats pollStats
+
+// PollStats are metrics on how long remote poll cycles are taking
+type PollStats struct {
+	Cycles       int           `json:"cycles"`       // number of poll cycles run since startup
+	LastStart    time.Time     `json:"lastStart"`    // when the last cycle started
+	LastDuration time.Duration `json:"lastDuration"` // how long the last cycle took in nanoseconds
+	AvgDuration  time.Duration `json:"avgDuration"`  // average cycle time in nanoseconds
+	MaxDuration  time.Duration `json:"maxDuration"`  // longest cycle time in nanoseconds
+	LastPolled   int           `json:"lastPolled"`   // folders listed in the last cycle
+	LastSkipped  int           `json:"lastSkipped"`  // folders skipped as unchanged in the last cycle
+	LastChanged  int           `json:"lastChanged"`  // folders with changes in the last cycle
+	LastErrors   int           `json:"lastErrors"`   // folders that failed to poll in the last cycle
+	Watched      int           `json:"watched"`      // folders currently being watched
+}
+
+type pollStats struct {
+	sync.Mutex
+	PollStats
+	total time.Duration
+}
+
+func (s *pollStats) record(start time.Time, duration time.Duration, result *pollResult) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.Cycles++
+	s.total += duration
+	s.LastStart = start
+	s.LastDuration = duration
+	s.AvgDuration = s.total / time.Duration(s.Cycles)
+	if duration > s.MaxDuration {
+		s.MaxDuration = duration
+	}
+	s.LastPolled = result.polled
+	s.LastSkipped = result.skipped
+	s.LastChanged = result.changed
+	s.LastErrors = result.errors
+}
+
+// Stats returns the current remote polling metrics
+func Stats() PollStats {
+	stats.Lock()
+	current := stats.PollStats
+	stats.Unlock()
+
+	watching.RLock()
+	current.Watched = len(watching.files)
+	watching.RUnlock()
+	return current
+}
+
+// pollResult collects the results of the folders polled in one cycle
+type pollResult struct {
+	sync.Mutex
+	polled, skipped, changed, errors int
+}
+
+func (r *pollResult) add(changed bool, err error) {
+	r.Lock()
+	defer r.Unlock()
+	r.polled++
+	if changed {
+		r.changed++
+	}
+	if err != nil {
+		r.errors++
+	}
+}
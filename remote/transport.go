@@ -0,0 +1,191 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	fh "bitbucket.org/tshannon/freehold-client"
+)
+
+// maxErrorBody is the most of an error response's body that will be read looking for a message
+const maxErrorBody = 64 * 1024
+
+// StatusError is returned for requests to the remote instance which are rejected, rate
+// limited or fail on the server, so the caller can tell whether or not retrying may help
+type StatusError struct {
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration // how long the server asked to wait before retrying, if it did
+}
+
+func (e *StatusError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Temporary returns whether or not the request may succeed if retried later
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Transport wraps an http.RoundTripper and records a StatusError for each bad request,
+// forbidden, rate limited and server error response.  Responses are passed on unchanged, and
+// the freehold client's error for a failed request can be traded for the recorded StatusError
+// with statusError
+type Transport struct {
+	Base http.RoundTripper // defaults to http.DefaultTransport
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusBadRequest,
+		res.StatusCode == http.StatusForbidden,
+		res.StatusCode == http.StatusTooManyRequests,
+		res.StatusCode >= 500:
+	default:
+		failures.clear(req.URL.Path)
+		return res, nil
+	}
+
+	statusErr := &StatusError{
+		StatusCode: res.StatusCode,
+		RetryAfter: retryAfter(res.Header.Get("Retry-After")),
+	}
+
+	// freehold responds with a jsend message explaining the error
+	body := struct {
+		Message string `json:"message"`
+	}{}
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, maxErrorBody))
+	if err == nil && json.Unmarshal(data, &body) == nil {
+		statusErr.Message = body.Message
+	}
+	// the body is still read by the freehold client
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), res.Body), res.Body}
+
+	failures.record(req.URL.Path, statusErr)
+	return res, nil
+}
+
+// failureExpiration is how long a recorded StatusError is kept for the freehold
+// client's error to be traded in
+const failureExpiration = time.Minute
+
+var failures = &failedRequests{
+	paths: make(map[string]*failedRequest),
+}
+
+type failedRequest struct {
+	err  *StatusError
+	when time.Time
+}
+
+// failedRequests are the StatusErrors of the last failed request to each url path
+type failedRequests struct {
+	sync.Mutex
+	paths map[string]*failedRequest
+}
+
+func (f *failedRequests) record(urlPath string, err *StatusError) {
+	urlPath = strings.TrimSuffix(urlPath, "/")
+	f.Lock()
+	defer f.Unlock()
+
+	for p, failed := range f.paths {
+		if time.Since(failed.when) > failureExpiration {
+			delete(f.paths, p)
+		}
+	}
+	f.paths[urlPath] = &failedRequest{
+		err:  err,
+		when: time.Now(),
+	}
+}
+
+func (f *failedRequests) clear(urlPath string) {
+	urlPath = strings.TrimSuffix(urlPath, "/")
+	f.Lock()
+	defer f.Unlock()
+	delete(f.paths, urlPath)
+}
+
+func (f *failedRequests) take(urlPath string) *StatusError {
+	urlPath = strings.TrimSuffix(urlPath, "/")
+	f.Lock()
+	defer f.Unlock()
+	failed, ok := f.paths[urlPath]
+	if !ok {
+		return nil
+	}
+	delete(f.paths, urlPath)
+	if time.Since(failed.when) > failureExpiration {
+		return nil
+	}
+	return failed.err
+}
+
+// statusError returns the StatusError recorded for a failed request to the url path, or to the
+// folder it's in, in place of the error the freehold client returned for it.  Errors from
+// requests that weren't rejected by the server are returned as is
+func statusError(err error, urlPath string) error {
+	if err == nil || fh.IsNotFound(err) {
+		return err
+	}
+	if statusErr := failures.take(urlPath); statusErr != nil {
+		return statusErr
+	}
+	if statusErr := failures.take(path.Dir(strings.TrimSuffix(urlPath, "/"))); statusErr != nil {
+		return statusErr
+	}
+	return err
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a number
+// of seconds or an http date
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		wait := when.Sub(time.Now())
+		if wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -0,0 +1,92 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package remote
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/file/busy/file.txt":
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"status":"error","message":"Slow down"}`))
+		case "/v1/file/forbidden/":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"status":"fail","message":"You do not have permissions"}`))
+		default:
+			w.Write([]byte(`{"status":"success"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &Transport{}}
+	clientErr := errors.New("client error")
+
+	tests := []struct {
+		name       string
+		request    string
+		checked    string
+		statusCode int
+		body       string
+		err        error
+	}{
+		{"success", "/v1/file/ok.txt", "/v1/file/ok.txt", http.StatusOK, `{"status":"success"}`, clientErr},
+		{"rate limited", "/v1/file/busy/file.txt", "/v1/file/busy/file.txt", http.StatusTooManyRequests,
+			`{"status":"error","message":"Slow down"}`,
+			&StatusError{StatusCode: http.StatusTooManyRequests, Message: "Slow down", RetryAfter: 2 * time.Minute}},
+		{"already taken", "/v1/file/ok.txt", "/v1/file/busy/file.txt", http.StatusOK, `{"status":"success"}`, clientErr},
+		{"folder of file", "/v1/file/forbidden/", "/v1/file/forbidden/new.txt", http.StatusForbidden,
+			`{"status":"fail","message":"You do not have permissions"}`,
+			&StatusError{StatusCode: http.StatusForbidden, Message: "You do not have permissions"}},
+		{"cleared by success", "/v1/file/busy/file.txt", "/v1/file/busy/file.txt", http.StatusTooManyRequests, "", clientErr},
+	}
+
+	for _, test := range tests {
+		res, err := client.Get(server.URL + test.request)
+		if err != nil {
+			t.Fatalf("%s: error making request: %s", test.name, err)
+		}
+		data, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: error reading response: %s", test.name, err)
+		}
+
+		if res.StatusCode != test.statusCode {
+			t.Errorf("%s: status code is %d, expected %d", test.name, res.StatusCode, test.statusCode)
+		}
+		if test.body != "" && string(data) != test.body {
+			t.Errorf("%s: body is %q, expected %q", test.name, data, test.body)
+		}
+
+		if test.name == "cleared by success" {
+			res, err = client.Get(server.URL + "/v1/file/busy/file.txt/")
+			if err != nil {
+				t.Fatalf("%s: error making request: %s", test.name, err)
+			}
+			res.Body.Close()
+		}
+
+		err = statusError(clientErr, test.checked)
+		if statusErr, ok := test.err.(*StatusError); ok {
+			got, ok := err.(*StatusError)
+			if !ok || *got != *statusErr {
+				t.Errorf("%s: error is %#v, expected %#v", test.name, err, statusErr)
+			}
+			continue
+		}
+		if err != test.err {
+			t.Errorf("%s: error is %#v, expected %#v", test.name, err, test.err)
+		}
+	}
+}
@@ -6,68 +6,271 @@ package main
 
 import (
 	"fmt"
+	"math/rand"
+	"net"
+	"net/url"
+	"os"
+	"sync"
+	"syscall"
 	"time"
 
-	"bitbucket.org/tshannon/freehold-sync/local"
 	"bitbucket.org/tshannon/freehold-sync/log"
 	"bitbucket.org/tshannon/freehold-sync/remote"
 	"bitbucket.org/tshannon/freehold-sync/syncer"
 )
 
+const (
+	maxRetries    = 3               // retries of a failed change before it's moved to the failure inbox
+	retryBaseWait = 5 * time.Second // wait before the first retry, doubled for each retry after
+	retryMaxWait  = 5 * time.Minute
+	retryJitter   = 0.2 // fraction of the wait randomly added or removed so retries don't bunch up
+)
+
 // retrier is for retrying errors
 type retrier interface {
 	//profile() *syncer.Profile
-	retry() error
-}
-
-func retryPoll() {
-	go func() {
-		// while there are errors to retry, wait until the profiles are idle / not actively syncing, and
-		// re-run the errors.  If they fail again, then log them.  This should clear up any order of operation issues
-		// that my pop up due to user activity
-		for r := range retry {
-			remote.PauseWatcher()
-			err := r.retry()
-			if err != nil {
-				retry <- r
-			}
-			remote.ResumeWatcher()
+	retry()
+}
+
+// retryQueue is an unbounded queue of errors waiting to be retried
+type retryQueue struct {
+	sync.Mutex
+	cond   *sync.Cond
+	items  []retrier
+	closed bool
+}
+
+func newRetryQueue() *retryQueue {
+	q := &retryQueue{}
+	q.cond = sync.NewCond(&q.Mutex)
+	return q
+}
+
+func (q *retryQueue) push(r retrier) {
+	q.Lock()
+	defer q.Unlock()
+	if q.closed {
+		return
+	}
+	q.items = append(q.items, r)
+	q.cond.Signal()
+}
+
+// pop blocks until there is an error to retry, returns nil once the queue is closed
+func (q *retryQueue) pop() retrier {
+	q.Lock()
+	defer q.Unlock()
+	for len(q.items) == 0 {
+		if q.closed {
+			return nil
 		}
-	}()
+		q.cond.Wait()
+	}
+	r := q.items[0]
+	q.items[0] = nil
+	q.items = q.items[1:]
+	return r
+}
+
+func (q *retryQueue) close() {
+	q.Lock()
+	defer q.Unlock()
+	q.closed = true
+	q.items = nil
+	q.cond.Broadcast()
+}
+
+// retryScheduler runs each profile's retries one at a time once they are due,
+// so retrying one profile's errors never holds up another profile
+type retryScheduler struct {
+	sync.Mutex
+	profiles map[string]*retryQueue
+	closed   bool
+}
+
+func newRetryScheduler() *retryScheduler {
+	return &retryScheduler{
+		profiles: make(map[string]*retryQueue),
+	}
+}
+
+// schedule queues up the retry on its profile after waiting
+func (s *retryScheduler) schedule(profileID string, r retrier, wait time.Duration) {
+	time.AfterFunc(wait, func() {
+		q := s.queue(profileID)
+		if q != nil {
+			q.push(r)
+		}
+	})
+}
+
+// queue returns the retry queue for the profile, starting it if it doesn't exist
+func (s *retryScheduler) queue(profileID string) *retryQueue {
+	s.Lock()
+	defer s.Unlock()
+	if s.closed {
+		return nil
+	}
+
+	q, ok := s.profiles[profileID]
+	if !ok {
+		q = newRetryQueue()
+		s.profiles[profileID] = q
+		go func() {
+			for r := q.pop(); r != nil; r = q.pop() {
+				r.retry()
+			}
+		}()
+	}
+	return q
+}
+
+func (s *retryScheduler) close() {
+	s.Lock()
+	defer s.Unlock()
+	s.closed = true
+	for _, q := range s.profiles {
+		q.close()
+	}
 }
 
 type syncRetry struct {
 	profile       *syncer.Profile
-	local, remote syncer.Syncer
-	logType       string
+	entry         *journalEntry
 	originalError error
-	retryCount    int
 }
 
-func (s *syncRetry) retry() error {
-	time.Sleep(5 * time.Second)
-	//Set deleted
-	l, err := local.New(s.local.ID())
+func (s *syncRetry) retry() {
+	l, r, err := s.entry.syncers(s.profile)
 	if err != nil {
-		log.New(fmt.Sprintf("Error building local syncer %s for retying error: %s", s.local.ID(), err.Error()), local.LogType)
-		return err
+		log.Error(fmt.Sprintf("Error retrying sync: %s", err), s.entry.LogType)
+		finishChange(s.profile, s.entry, err)
+		return
 	}
-	l.SetDeleted(s.local.Deleted())
-	r, err := remote.New(s.remote.(*remote.File).Client(), s.remote.(*remote.File).URL)
-	if err != nil {
-		log.New(fmt.Sprintf("Error building remote syncer %s for retying error: %s", s.remote.ID(), err.Error()), remote.LogType)
-		return err
+
+	finishChange(s.profile, s.entry, s.profile.Sync(l, r))
+}
+
+// finishChange handles the result of syncing a journaled change.  Transient errors are
+// retried with an increasing wait, permanent errors and changes out of retries are moved
+// to the failure inbox
+func finishChange(p *syncer.Profile, entry *journalEntry, err error) {
+	if err == syncer.ErrShuttingDown {
+		// left in the journal to run the next time freehold-sync starts
+		return
+	}
+	if err == syncer.ErrProfileStopped {
+		entry.remove()
+		return
+	}
+	if err == nil {
+		breaker.success(p.ID())
+		clearFailure(entry)
+		recordFingerprint(entry)
+		entry.remove()
+		return
 	}
-	r.SetDeleted(s.remote.Deleted())
 
-	err = s.profile.Sync(l, r)
-	if err != nil {
-		s.retryCount++
-		if s.retryCount >= 3 {
-			//after 3 attempts log error and don't retry again
-			log.New(fmt.Sprintf("Error with syncing %s and %s retrying.  Error: %s\n", r.ID(), l.ID(), err), s.logType)
-			return nil
+	if holdIfRootUnavailable(p, entry, err) {
+		return
+	}
+	if holdIfMassChange(p, entry, err) {
+		return
+	}
+	if holdIfSuspicious(p, entry, err) {
+		return
+	}
+	if holdIfOffline(p, entry, err) {
+		return
+	}
+	if holdIfDiskFull(p, entry, err) {
+		return
+	}
+
+	breaker.failure(p.ID())
+	entry.Attempts++
+
+	transient, retryAfter := classifyError(err)
+	if !transient || entry.Attempts > maxRetries {
+		log.Error(fmt.Sprintf("Error with syncing %s and %s.  Error: %s\n", entry.Remote, entry.Local, err), entry.LogType,
+			log.Fields{
+				Profile: p.ID(),
+				Path:    entry.path(p),
+				Change:  changeType(err),
+			})
+		failErr := recordFailure(entry, err)
+		if failErr != nil {
+			log.Error(fmt.Sprintf("Error recording failed change in the failure inbox: %s", failErr), entry.LogType)
+		}
+		entry.remove()
+		return
+	}
+
+	entry.save()
+	retries.schedule(p.ID(), &syncRetry{
+		profile:       p,
+		entry:         entry,
+		originalError: err,
+	}, retryWait(entry.Attempts, retryAfter))
+}
+
+// retryWait returns how long to wait before the passed in retry attempt
+func retryWait(attempt int, retryAfter time.Duration) time.Duration {
+	wait := retryMaxWait
+	if attempt < 16 {
+		wait = retryBaseWait << uint(attempt-1)
+		if wait > retryMaxWait {
+			wait = retryMaxWait
+		}
+	}
+
+	spread := int64(float64(wait) * retryJitter)
+	if spread > 0 {
+		wait += time.Duration(rand.Int63n(2*spread) - spread)
+	}
+
+	if retryAfter > wait {
+		return retryAfter
+	}
+	return wait
+}
+
+// classifyError returns whether or not the error is transient and worth retrying, and how
+// long the remote server asked to wait before retrying if it did.  Errors that can't be
+// identified are treated as transient, as they are usually order of operation issues
+func classifyError(err error) (transient bool, retryAfter time.Duration) {
+	switch e := unwrapError(err).(type) {
+	case *remote.StatusError:
+		return e.Temporary(), e.RetryAfter
+	case syscall.Errno:
+		switch e {
+		case syscall.ENOSPC, syscall.EACCES, syscall.EPERM, syscall.EROFS,
+			syscall.ENAMETOOLONG, syscall.EINVAL:
+			return false, 0
+		}
+	}
+
+	return true, 0
+}
+
+// unwrapError returns the underlying cause of the error
+func unwrapError(err error) error {
+	for {
+		switch e := err.(type) {
+		case *syncer.ChangeError:
+			err = e.Err
+		case *url.Error:
+			err = e.Err
+		case *os.PathError:
+			err = e.Err
+		case *os.LinkError:
+			err = e.Err
+		case *os.SyscallError:
+			err = e.Err
+		case *net.OpError:
+			err = e.Err
+		default:
+			return err
 		}
 	}
-	return err
 }
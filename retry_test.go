@@ -0,0 +1,82 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/remote"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+func TestRetryWait(t *testing.T) {
+	tests := []struct {
+		attempt    int
+		retryAfter time.Duration
+		base       time.Duration // wait before jitter
+	}{
+		{1, 0, retryBaseWait},
+		{2, 0, 2 * retryBaseWait},
+		{4, 0, 8 * retryBaseWait},
+		{7, 0, retryMaxWait},
+		{16, 0, retryMaxWait},
+		{100, 0, retryMaxWait},
+		{1, time.Hour, time.Hour},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 20; i++ {
+			wait := retryWait(test.attempt, test.retryAfter)
+			if test.retryAfter > 0 {
+				if wait != test.retryAfter {
+					t.Fatalf("Attempt %d waits %s, expected the %s the server asked for", test.attempt, wait,
+						test.retryAfter)
+				}
+				continue
+			}
+			spread := time.Duration(float64(test.base) * retryJitter)
+			if wait < test.base-spread || wait > test.base+spread {
+				t.Fatalf("Attempt %d waits %s, expected %s +/- %s", test.attempt, wait, test.base, spread)
+			}
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		transient  bool
+		retryAfter time.Duration
+	}{
+		{"unknown", errors.New("unknown"), true, 0},
+		{"server error", &remote.StatusError{StatusCode: 503}, true, 0},
+		{"rate limited", &remote.StatusError{StatusCode: 429, RetryAfter: time.Minute}, true, time.Minute},
+		{"forbidden", &remote.StatusError{StatusCode: 403}, false, 0},
+		{"bad request", &remote.StatusError{StatusCode: 400}, false, 0},
+		{"wrapped status", &syncer.ChangeError{Change: "write", Err: &url.Error{Op: "Put", URL: "/v1/file/a",
+			Err: &remote.StatusError{StatusCode: 500}}}, true, 0},
+		{"permission denied", &os.PathError{Op: "open", Path: "/a", Err: syscall.EACCES}, false, 0},
+		{"disk full", &syncer.ChangeError{Change: "write", Err: &os.PathError{Op: "write", Path: "/a",
+			Err: syscall.ENOSPC}}, false, 0},
+		{"name too long", &os.PathError{Op: "open", Path: "/a", Err: syscall.ENAMETOOLONG}, false, 0},
+		{"file busy", &os.PathError{Op: "open", Path: "/a", Err: syscall.EBUSY}, true, 0},
+	}
+
+	for _, test := range tests {
+		transient, retryAfter := classifyError(test.err)
+		if transient != test.transient {
+			t.Errorf("%s: transient is %t, expected %t", test.name, transient, test.transient)
+		}
+		if retryAfter != test.retryAfter {
+			t.Errorf("%s: retry after is %s, expected %s", test.name, retryAfter, test.retryAfter)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/log"
+	"bitbucket.org/tshannon/freehold-sync/remote"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+// Before a change runs, the local starting point of its profile is checked for the marker
+// file written when the profile first started, and before anything is deleted the remote
+// starting point is checked for its marker as well.  If either is missing, such as when an
+// external drive is unplugged, the profile is paused instead of syncing the missing files
+// as deletes, and resumed once the starting point is back
+const (
+	pauseRootUnavailable = "root unavailable"
+	rootCheckInterval    = 30 * time.Second
+	remoteCheckCache     = 10 * time.Second // how long a successful check of the remote root is trusted
+)
+
+var unavailable rootGuard
+
+func init() {
+	unavailable = rootGuard{
+		profiles: make(map[string][]*journalEntry),
+	}
+}
+
+// rootError is returned when a profile's starting point is missing
+type rootError struct {
+	err error
+}
+
+func (e *rootError) Error() string {
+	return e.err.Error()
+}
+
+// checkRoots returns the check of the profile's local and remote starting points, which is
+// run before each of its changes.  A successful check of the remote starting point is reused
+// for a short while, so a batch of deletes doesn't check it for every file.  Profiles created
+// before roots were marked only have their local starting point checked, until they are re-marked
+func (p *profileStore) checkRoots(profile *syncer.Profile) func(deleting bool) error {
+	marker := p.RootMarker
+	remoteMarked := p.RemoteMarked
+	var lock sync.Mutex
+	var remoteChecked time.Time
+
+	return func(deleting bool) error {
+		if marker == "" {
+			if _, err := os.Stat(profile.Local.ID()); err != nil {
+				return &rootError{fmt.Errorf("The local sync root %s is missing", profile.Local.ID())}
+			}
+		} else {
+			err := local.CheckMarker(profile.Local.ID(), marker)
+			if err != nil {
+				return &rootError{err}
+			}
+		}
+		if !deleting || !remoteMarked {
+			return nil
+		}
+
+		lock.Lock()
+		defer lock.Unlock()
+		if time.Since(remoteChecked) < remoteCheckCache {
+			return nil
+		}
+
+		err := remote.CheckMarker(profile.Remote.(*remote.File), marker)
+		if _, ok := err.(*remote.MarkerError); ok {
+			return &rootError{err}
+		}
+		if err != nil {
+			return err
+		}
+		remoteChecked = time.Now()
+		return nil
+	}
+}
+
+// rootGuard holds the changes of profiles paused because a starting point is missing
+type rootGuard struct {
+	sync.Mutex
+	profiles map[string][]*journalEntry
+}
+
+// holdIfRootUnavailable holds the change and pauses the profile if the change wasn't run
+// because one of the profile's starting points is missing
+func holdIfRootUnavailable(p *syncer.Profile, entry *journalEntry, err error) bool {
+	rootErr, ok := unwrapError(err).(*rootError)
+	if !ok {
+		return false
+	}
+
+	saveErr := entry.save()
+	if saveErr != nil {
+		log.Error(fmt.Sprintf("Error journaling change to %s: %s", entry.Local, saveErr), entry.LogType)
+	}
+	unavailable.hold(p, entry, rootErr)
+	return true
+}
+
+// hold adds the change to the profile's held changes, pausing the profile if it isn't already
+func (g *rootGuard) hold(p *syncer.Profile, entry *journalEntry, err error) {
+	g.Lock()
+	defer g.Unlock()
+
+	entries, ok := g.profiles[p.ID()]
+	if !ok {
+		syncer.PauseFor(p.ID(), pauseRootUnavailable)
+		log.Warn(fmt.Sprintf("Pausing profile %s until its sync root is available, nothing will be deleted "+
+			"in the meantime.  Error: %s", p.ID(), err), "Both", log.Fields{Profile: p.ID()})
+		go g.watch(p)
+	}
+	g.profiles[p.ID()] = append(entries, entry)
+}
+
+// watch checks the profile's starting points until they are both available again, or
+// the profile is stopped or restarted
+func (g *rootGuard) watch(p *syncer.Profile) {
+	for {
+		time.Sleep(rootCheckInterval)
+		if syncer.Running(p.ID()) != p {
+			// the whole profile is synced again when it starts, which picks up the held changes
+			g.release(p.ID())
+			return
+		}
+		if g.resume(p) {
+			return
+		}
+	}
+}
+
+// resume resumes the profile and syncs its held changes if its starting points are available
+func (g *rootGuard) resume(p *syncer.Profile) bool {
+	if p.CheckRoots != nil && p.CheckRoots(true) != nil {
+		return false
+	}
+
+	g.Lock()
+	defer g.Unlock()
+	entries, ok := g.profiles[p.ID()]
+	if !ok {
+		return true
+	}
+
+	delete(g.profiles, p.ID())
+	syncer.ResumeFrom(p.ID(), pauseRootUnavailable)
+	log.Info(fmt.Sprintf("Resuming profile %s now that its sync root is available.", p.ID()), "Both",
+		log.Fields{Profile: p.ID()})
+
+	go resyncEntries(p, entries)
+	return true
+}
+
+// release drops the changes held for the profile and resumes it, for when the profile
+// is synced again as a whole
+func (g *rootGuard) release(profileID string) {
+	g.Lock()
+	delete(g.profiles, profileID)
+	g.Unlock()
+	syncer.ResumeFrom(profileID, pauseRootUnavailable)
+}
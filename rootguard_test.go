@@ -0,0 +1,72 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bitbucket.org/tshannon/freehold-sync/local"
+	"bitbucket.org/tshannon/freehold-sync/syncer"
+)
+
+func TestCheckRootsLocal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freehold-sync-rootguard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	marked := filepath.Join(dir, "marked")
+	unmarked := filepath.Join(dir, "unmarked")
+	for _, root := range []string{marked, unmarked} {
+		err = os.Mkdir(root, 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	marker, err := local.Mark(marked)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		root    string
+		marker  string
+		remove  string
+		missing bool
+	}{
+		{"marked", marked, marker, "", false},
+		{"wrong marker", marked, "other", "", true},
+		{"unmarked", unmarked, "", "", false},
+		{"marker removed", marked, marker, filepath.Join(marked, local.MarkerName), true},
+		{"unmarked root removed", unmarked, "", unmarked, true},
+	}
+
+	for _, test := range tests {
+		if test.remove != "" {
+			err = os.Remove(test.remove)
+			if err != nil {
+				t.Fatalf("%s: error removing %s: %s", test.name, test.remove, err)
+			}
+		}
+
+		root, err := local.New(test.root)
+		if err != nil {
+			t.Fatalf("%s: error opening root: %s", test.name, err)
+		}
+		// the remote root isn't checked unless it was marked
+		p := &profileStore{RootMarker: test.marker}
+		check := p.checkRoots(&syncer.Profile{Local: root})
+
+		err = check(true)
+		if _, ok := err.(*rootError); ok != test.missing {
+			t.Errorf("%s: root missing is %t, expected %t.  Error: %v", test.name, ok, test.missing, err)
+		}
+	}
+}
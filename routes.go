@@ -17,9 +17,19 @@ func init() {
 	/profile:
 		Get: Retrieve Sync Profiles
 		Post: Post new Sync Profile
-		Put: Update existing Sync Profile
+		Put: Update existing Sync Profile, settings left out keep their current values
 	/profile/status:
 		Get: Retrieve sync status of a specific sync profile
+	/profile/stats:
+		Get: Retrieve daily and weekly sync totals of a specific sync profile
+	/profile/pause:
+		Post: Pause running changes on a sync profile, or all profiles
+	/profile/resume:
+		Post: Resume running changes on a sync profile, or all profiles
+	/profile/syncnow:
+		Post: Force a full local and remote rescan of a sync profile
+	/profile/remark:
+		Post: Mark the current local and remote folders of a sync profile as its sync roots
 	/local:
 		Get: Get local file Directory listings for Sync profile selection
 	/local/root:
@@ -30,8 +40,30 @@ func init() {
 		Get: Get remote starting point
 	/remote/token:
 		Post: Get token from user / password
+	/remote/stats:
+		Get: Get remote polling metrics
 	/log:
 		Get: Get logs
+	/log/export:
+		Get: Export logs as csv or json lines
+	/activity:
+		Get: Get changes made while syncing
+	/failure:
+		Get: Get changes which failed to sync after all retries
+	/failure/retry:
+		Post: Queue a failed change, or all failed changes back up for syncing
+	/failure/dismiss:
+		Post: Remove a failed change, or all failed changes from the failure inbox
+	/held:
+		Get: Get deletes and overwrites held for confirmation
+	/held/confirm:
+		Post: Run the changes held for a profile, or all profiles
+	/held/reject:
+		Post: Undo the changes held for a profile, or all profiles
+	/instance:
+		Get: Get the PID and web port of this instance
+	/instance/show:
+		Post: Open the web interface in the default browser, only accepted from this machine
 */
 
 func setupRoutes() {
@@ -45,6 +77,44 @@ func setupRoutes() {
 	rootHandler.Handle("/log/", &methodHandler{
 		get: logGet,
 	})
+	rootHandler.Handle("/log/export/", &methodHandler{
+		get: logExportGet,
+	})
+
+	//Activity
+	rootHandler.Handle("/activity/", &methodHandler{
+		get: activityGet,
+	})
+
+	//Failures
+	rootHandler.Handle("/failure/", &methodHandler{
+		get: failureGet,
+	})
+	rootHandler.Handle("/failure/retry/", &methodHandler{
+		post: failureRetryPost,
+	})
+	rootHandler.Handle("/failure/dismiss/", &methodHandler{
+		post: failureDismissPost,
+	})
+
+	//Held changes
+	rootHandler.Handle("/held/", &methodHandler{
+		get: heldGet,
+	})
+	rootHandler.Handle("/held/confirm/", &methodHandler{
+		post: heldConfirmPost,
+	})
+	rootHandler.Handle("/held/reject/", &methodHandler{
+		post: heldRejectPost,
+	})
+
+	//Instance
+	rootHandler.Handle("/instance/", &methodHandler{
+		get: instanceGet,
+	})
+	rootHandler.Handle("/instance/show/", &methodHandler{
+		post: instanceShowPost,
+	})
 
 	//Local
 	rootHandler.Handle("/local/", &methodHandler{
@@ -65,6 +135,9 @@ func setupRoutes() {
 	rootHandler.Handle("/remote/token/", &methodHandler{
 		post: tokenPost,
 	})
+	rootHandler.Handle("/remote/stats/", &methodHandler{
+		get: remoteStatsGet,
+	})
 
 	//Profiles
 	rootHandler.Handle("/profile/", &methodHandler{
@@ -77,6 +150,26 @@ func setupRoutes() {
 	rootHandler.Handle("/profile/status/", &methodHandler{
 		get: profileStatusGet,
 	})
+
+	rootHandler.Handle("/profile/stats/", &methodHandler{
+		get: profileStatsGet,
+	})
+
+	rootHandler.Handle("/profile/pause/", &methodHandler{
+		post: profilePausePost,
+	})
+
+	rootHandler.Handle("/profile/resume/", &methodHandler{
+		post: profileResumePost,
+	})
+
+	rootHandler.Handle("/profile/syncnow/", &methodHandler{
+		post: profileSyncNowPost,
+	})
+
+	rootHandler.Handle("/profile/remark/", &methodHandler{
+		post: profileRemarkPost,
+	})
 }
 
 type methodHandler struct {
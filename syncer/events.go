@@ -0,0 +1,111 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package syncer
+
+import "sync"
+
+// EventHandler is called for each change event a monitor finds on a profile
+type EventHandler func(*Profile, Syncer)
+
+type event struct {
+	syncer  Syncer
+	handler EventHandler
+}
+
+// eventQueue holds the change events found by the monitors for a profile, and hands
+// them out in the order they arrived.  A new event for a file that is already queued
+// replaces the queued one in place, so a paused profile holds at most one event per file
+type eventQueue struct {
+	sync.Mutex
+	cond   *sync.Cond
+	order  []string
+	events map[string]*event
+	closed bool
+}
+
+func newEventQueue() *eventQueue {
+	q := &eventQueue{
+		events: make(map[string]*event),
+	}
+	q.cond = sync.NewCond(&q.Mutex)
+	return q
+}
+
+// push adds an event to the end of the queue, or replaces the queued event for the
+// same file.  Returns false if the queue is closed
+func (q *eventQueue) push(e *event) bool {
+	q.Lock()
+	defer q.Unlock()
+	if q.closed {
+		return false
+	}
+	id := e.syncer.ID()
+	if _, ok := q.events[id]; !ok {
+		q.order = append(q.order, id)
+	}
+	q.events[id] = e
+	q.cond.Signal()
+	return true
+}
+
+// pop blocks until an event is available and removes it from the front of the queue.
+// Returns false once the queue is closed
+func (q *eventQueue) pop() (*event, bool) {
+	q.Lock()
+	defer q.Unlock()
+	for len(q.order) == 0 {
+		if q.closed {
+			return nil, false
+		}
+		q.cond.Wait()
+	}
+	id := q.order[0]
+	q.order = q.order[1:]
+	e := q.events[id]
+	delete(q.events, id)
+	return e, true
+}
+
+// close closes the queue and drops any events still in it
+func (q *eventQueue) close() {
+	q.Lock()
+	defer q.Unlock()
+	q.closed = true
+	q.order = nil
+	q.events = make(map[string]*event)
+	q.cond.Broadcast()
+}
+
+func (q *eventQueue) len() int {
+	q.Lock()
+	defer q.Unlock()
+	return len(q.order)
+}
+
+// QueueEvent queues up a change event found by a monitor for this profile.  Events are
+// handled one at a time in the order they arrive, without blocking the monitor
+// that found them.  Events for a stopped profile are dropped
+func (p *Profile) QueueEvent(s Syncer, handler EventHandler) {
+	if p.events == nil {
+		// profile isn't running
+		handler(p, s)
+		return
+	}
+	p.events.push(&event{syncer: s, handler: handler})
+}
+
+// handleEvents runs the profile's events until its event queue is closed
+func (p *Profile) handleEvents(events *eventQueue) {
+	for {
+		e, ok := events.pop()
+		if !ok {
+			return
+		}
+		if isShuttingDown() {
+			continue
+		}
+		e.handler(p, e.syncer)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package syncer
+
+import "testing"
+
+func TestEventQueue(t *testing.T) {
+	tests := []struct {
+		name   string
+		pushed []string
+		popped []string
+	}{
+		{"in order", []string{"a", "b", "c"}, []string{"a", "b", "c"}},
+		{"repeat keeps first place", []string{"a", "b", "a", "c"}, []string{"a", "b", "c"}},
+		{"many repeats", []string{"a", "a", "a", "b", "b"}, []string{"a", "b"}},
+	}
+
+	for _, test := range tests {
+		q := newEventQueue()
+		last := make(map[string]*testSyncer)
+		for _, id := range test.pushed {
+			s := &testSyncer{id: id}
+			last[id] = s
+			if !q.push(&event{syncer: s}) {
+				t.Fatalf("%s: push to an open queue failed", test.name)
+			}
+		}
+		if q.len() != len(test.popped) {
+			t.Fatalf("%s: queue length is %d, expected %d", test.name, q.len(), len(test.popped))
+		}
+
+		for _, id := range test.popped {
+			e, ok := q.pop()
+			if !ok {
+				t.Fatalf("%s: pop failed", test.name)
+			}
+			if e.syncer.ID() != id {
+				t.Fatalf("%s: popped %s, expected %s", test.name, e.syncer.ID(), id)
+			}
+			if e.syncer != last[id] {
+				t.Fatalf("%s: popped an older event for %s than the last one pushed", test.name, id)
+			}
+		}
+
+		q.close()
+		if q.push(&event{syncer: &testSyncer{id: "a"}}) {
+			t.Fatalf("%s: push to a closed queue succeeded", test.name)
+		}
+		if _, ok := q.pop(); ok {
+			t.Fatalf("%s: pop from a closed queue succeeded", test.name)
+		}
+	}
+}
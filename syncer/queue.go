@@ -0,0 +1,81 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package syncer
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrProfileStopped is returned for changes queued on a profile that has been stopped
+var ErrProfileStopped = errors.New("The sync profile has been stopped")
+
+// changeQueue is an unbounded first in first out queue of changes, so
+// producers never block waiting on a profile's changes to run
+type changeQueue struct {
+	sync.Mutex
+	cond   *sync.Cond
+	items  []*changeItem
+	closed bool
+}
+
+func newChangeQueue() *changeQueue {
+	q := &changeQueue{}
+	q.cond = sync.NewCond(&q.Mutex)
+	return q
+}
+
+// push adds a change to the end of the queue, returns false if the queue is closed
+func (q *changeQueue) push(c *changeItem) bool {
+	q.Lock()
+	defer q.Unlock()
+	if q.closed {
+		return false
+	}
+	q.items = append(q.items, c)
+	q.cond.Signal()
+	return true
+}
+
+// pop blocks until a change is available and removes it from the front of the queue.
+// Returns false once the queue is closed and all changes in it have been taken
+func (q *changeQueue) pop() (*changeItem, bool) {
+	q.Lock()
+	defer q.Unlock()
+	for len(q.items) == 0 {
+		if q.closed {
+			return nil, false
+		}
+		q.cond.Wait()
+	}
+	c := q.items[0]
+	q.items[0] = nil
+	q.items = q.items[1:]
+	return c, true
+}
+
+func (q *changeQueue) close() {
+	q.Lock()
+	defer q.Unlock()
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// drain closes the queue and returns the changes still in it
+func (q *changeQueue) drain() []*changeItem {
+	q.Lock()
+	defer q.Unlock()
+	q.closed = true
+	items := q.items
+	q.items = nil
+	q.cond.Broadcast()
+	return items
+}
+
+func (q *changeQueue) len() int {
+	q.Lock()
+	defer q.Unlock()
+	return len(q.items)
+}
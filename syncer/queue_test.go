@@ -0,0 +1,63 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package syncer
+
+import "testing"
+
+func TestChangeQueue(t *testing.T) {
+	tests := []struct {
+		name    string
+		pushed  int
+		closed  bool
+		drained bool
+		popped  int // changes pop returns before reporting the queue is done
+	}{
+		{"open", 3, false, false, 3},
+		{"closed", 3, true, false, 3},
+		{"drained", 3, false, true, 0},
+	}
+
+	for _, test := range tests {
+		q := newChangeQueue()
+		items := make([]*changeItem, test.pushed)
+		for i := range items {
+			items[i] = &changeItem{}
+			if !q.push(items[i]) {
+				t.Fatalf("%s: push to an open queue failed", test.name)
+			}
+		}
+		if q.len() != test.pushed {
+			t.Fatalf("%s: queue length is %d, expected %d", test.name, q.len(), test.pushed)
+		}
+
+		if test.closed {
+			q.close()
+		}
+		if test.drained {
+			pending := q.drain()
+			if len(pending) != test.pushed {
+				t.Fatalf("%s: drained %d changes, expected %d", test.name, len(pending), test.pushed)
+			}
+		}
+		if (test.closed || test.drained) && q.push(&changeItem{}) {
+			t.Fatalf("%s: push to a closed queue succeeded", test.name)
+		}
+
+		for i := 0; i < test.popped; i++ {
+			c, ok := q.pop()
+			if !ok {
+				t.Fatalf("%s: pop %d failed", test.name, i)
+			}
+			if c != items[i] {
+				t.Fatalf("%s: pop %d returned changes out of order", test.name, i)
+			}
+		}
+		if test.closed || test.drained {
+			if _, ok := q.pop(); ok {
+				t.Fatalf("%s: pop from an empty closed queue succeeded", test.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package syncer
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// ErrShuttingDown is returned for changes that weren't run because freehold-sync is shutting down
+var ErrShuttingDown = errors.New("Freehold-Sync is shutting down")
+
+var shuttingDown int32
+
+func isShuttingDown() bool {
+	return atomic.LoadInt32(&shuttingDown) == 1
+}
+
+// Shutdown stops every running profile from taking on new changes, and waits up to the
+// timeout for the changes already running to finish.  Changes that haven't started are
+// returned ErrShuttingDown.  Returns false if the timeout was reached first
+func Shutdown(timeout time.Duration) bool {
+	atomic.StoreInt32(&shuttingDown, 1)
+	// wake up paused profiles so they can drop their changes
+	paused.release()
+
+	profiles := running.all()
+	for i := range profiles {
+		if profiles[i].events != nil {
+			profiles[i].events.close()
+		}
+		if profiles[i].changes == nil {
+			continue
+		}
+		pending := profiles[i].changes.drain()
+		for j := range pending {
+			pending[j].done <- ErrShuttingDown
+		}
+	}
+
+	deadline := time.After(timeout)
+	for i := range profiles {
+		if profiles[i].stopped == nil {
+			continue
+		}
+		select {
+		case <-profiles[i].stopped:
+		case <-deadline:
+			return false
+		}
+	}
+	return true
+}
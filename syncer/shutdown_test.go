@@ -0,0 +1,109 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package syncer
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testSyncer is a syncer with only an ID
+type testSyncer struct {
+	Syncer
+	id string
+}
+
+func (s *testSyncer) ID() string {
+	return s.id
+}
+
+func TestShutdown(t *testing.T) {
+	defer atomic.StoreInt32(&shuttingDown, 0)
+
+	tests := []struct {
+		name    string
+		running time.Duration // how long the change already running takes, none if 0
+		pending int           // changes waiting to run
+		timeout time.Duration
+		drained bool
+	}{
+		{"idle", 0, 0, 20 * time.Millisecond, true},
+		{"pending only", 0, 3, 20 * time.Millisecond, true},
+		{"running finishes", 20 * time.Millisecond, 3, time.Second, true},
+		{"running times out", 500 * time.Millisecond, 3, 20 * time.Millisecond, false},
+	}
+
+	for _, test := range tests {
+		atomic.StoreInt32(&shuttingDown, 0)
+		p := &Profile{
+			Local:  &testSyncer{id: "/home/user/docs"},
+			Remote: &testSyncer{id: test.name},
+		}
+		p.changes = newChangeQueue()
+		p.stopped = make(chan struct{})
+		running.add(p)
+
+		// runs changes like a started profile, the first one taking the running time.  The
+		// profile is paused after it starts, so the pending changes are still waiting
+		started := make(chan struct{})
+		go func(changes *changeQueue, stopped chan struct{}, runFor time.Duration) {
+			defer close(stopped)
+			for {
+				change, ok := changes.pop()
+				if !ok {
+					return
+				}
+				paused.wait(p.ID())
+				if isShuttingDown() {
+					change.done <- ErrShuttingDown
+					continue
+				}
+				close(started)
+				time.Sleep(runFor)
+				change.done <- nil
+			}
+		}(p.changes, p.stopped, test.running)
+
+		var current *changeItem
+		if test.running > 0 {
+			current = &changeItem{done: make(chan error, 1)}
+			p.changes.push(current)
+			<-started
+		}
+		Pause(p.ID())
+		pending := make([]*changeItem, test.pending)
+		for i := range pending {
+			pending[i] = &changeItem{done: make(chan error, 1)}
+			p.changes.push(pending[i])
+		}
+
+		if drained := Shutdown(test.timeout); drained != test.drained {
+			t.Errorf("%s: drained is %t, expected %t", test.name, drained, test.drained)
+		}
+		for i := range pending {
+			select {
+			case err := <-pending[i].done:
+				if err != ErrShuttingDown {
+					t.Errorf("%s: pending change %d returned %v, expected %s", test.name, i, err, ErrShuttingDown)
+				}
+			default:
+				t.Errorf("%s: pending change %d wasn't returned an error", test.name, i)
+			}
+		}
+		if p.changes.push(&changeItem{done: make(chan error, 1)}) {
+			t.Errorf("%s: a change was queued after shutting down", test.name)
+		}
+
+		<-p.stopped
+		if current != nil {
+			if err := <-current.done; err != nil {
+				t.Errorf("%s: running change returned %s, expected it to finish", test.name, err)
+			}
+		}
+		running.remove(p)
+		Resume(p.ID())
+	}
+}
@@ -6,13 +6,27 @@ package syncer
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"time"
+
+	"bitbucket.org/tshannon/freehold-sync/activity"
+	"bitbucket.org/tshannon/freehold-sync/log"
 )
 
-var syncing syncingData // tracks which profiles are currently syncing
+// LogType is the log type for changes made while syncing
+const LogType = "Both"
+
+var (
+	syncing syncingData     // tracks which profiles are currently syncing
+	paused  pausedData      // tracks which profiles have their queued changes suspended
+	running runningProfiles // profiles that have been started
+)
 
 //right now changes are happening single threaded. In order to make multiple changes concurrently
 // we'll need to build a dependency graph; i.e. you can't run deletes on a directory you are write a file to, etc
@@ -25,6 +39,13 @@ func init() {
 	syncing = syncingData{
 		profiles: make(map[string]int),
 	}
+	paused = pausedData{
+		profiles: make(map[string]map[string]struct{}),
+	}
+	paused.cond = sync.NewCond(&paused.Mutex)
+	running = runningProfiles{
+		profiles: make(map[string]*Profile),
+	}
 }
 
 // Direction determines which way a sync will move files
@@ -53,6 +74,23 @@ const (
 	changeTypeCreateDir
 )
 
+var changeNames = map[int]string{
+	changeTypeWrite:     "write",
+	changeTypeDelete:    "delete",
+	changeTypeRename:    "rename",
+	changeTypeCreateDir: "createDir",
+}
+
+// ChangeError is returned from a sync when the change it queued up failed
+type ChangeError struct {
+	Change string // the type of change that failed: write, delete, rename or createDir
+	Err    error
+}
+
+func (e *ChangeError) Error() string {
+	return e.Err.Error()
+}
+
 // Syncer is used for comparing two files local or remote
 // to determine which one should be overwritten based on
 // the sync profile rules
@@ -87,16 +125,23 @@ type Syncer interface {
 // If there is no conflict and the file's modified dates don't match, the
 // older file is overwritten
 type Profile struct {
-	Name               string           //Name of the profile
-	Direction          int              //direction to sync files
-	ConflictResolution int              //Method for handling when there is a sync conflict between two files
-	ConflictDuration   time.Duration    //Duration between to file's modified times to determine if there is a conflict
-	Ignore             []*regexp.Regexp //List of regular expressions of filepaths to ignore if they match
+	Name               string                               //Name of the profile
+	Direction          int                                  //direction to sync files
+	ConflictResolution int                                  //Method for handling when there is a sync conflict between two files
+	ConflictDuration   time.Duration                        //Duration between to file's modified times to determine if there is a conflict
+	Ignore             []*regexp.Regexp                     //List of regular expressions of filepaths to ignore if they match
+	PollLocal          bool                                 //Poll the local folders for changes instead of relying on file system events
+	RemotePollInterval time.Duration                        //How often to poll for remote changes, 0 uses the default
+	CheckRoots         func(deleting bool) error            //Verifies the starting points are still available before a change runs, nil skips the check
+	CheckDestructive   func(change string, to Syncer) error //Called before a change deletes or overwrites a file, an error holds the change back, nil skips the check
+	CheckWrite         func(from, to Syncer) error          //Called before a file is written, an error holds the change back, nil skips the check
 
 	Local  Syncer //Local starting point for syncing
 	Remote Syncer // Remote starting point for syncing
 
-	changes chan *changeItem // collects all changes as they come in and runs them in the order they arrive
+	changes *changeQueue  // collects all changes as they come in and runs them in the order they arrive
+	events  *eventQueue   // change events found by the monitors, handled in the order they arrive
+	stopped chan struct{} // closed once the profile has stopped running changes
 }
 
 // ID uniquely identifies a profile.  Is a combination of
@@ -114,16 +159,32 @@ func (p *Profile) Start() error {
 		return errors.New("Remote sync starting point not set.")
 	}
 
-	p.changes = make(chan *changeItem, 200)
+	p.changes = newChangeQueue()
+	p.events = newEventQueue()
+	p.stopped = make(chan struct{})
+	go p.handleEvents(p.events)
 	go func() {
 		p.Sync(p.Local, p.Remote)
 	}()
-	go func() {
-		for change := range p.changes {
+	go func(changes *changeQueue, stopped chan struct{}) {
+		defer close(stopped)
+		for {
+			change, ok := changes.pop()
+			if !ok {
+				return
+			}
+			// hold changes while the profile is paused, they'll run
+			// in the order they arrived once it's resumed
+			paused.wait(p.ID())
+			if isShuttingDown() {
+				change.done <- ErrShuttingDown
+				continue
+			}
 			change.runChange()
 		}
-	}()
+	}(p.changes, p.stopped)
 
+	running.add(p)
 	return nil
 }
 
@@ -138,8 +199,12 @@ func (p *Profile) Stop() error {
 		return err
 	}
 
+	running.remove(p)
+	if p.events != nil {
+		p.events.close()
+	}
 	if p.changes != nil {
-		close(p.changes)
+		p.changes.close()
 	}
 	return nil
 }
@@ -262,6 +327,7 @@ func (p *Profile) Sync(local, remote Syncer) error {
 
 	//check for conflict
 	if p.isConflict(before.Modified(), after.Modified()) {
+		activity.Conflict(p.ID())
 		//resolve conflict
 		if p.ConflictResolution == ConResRename {
 			return <-p.rename(before)
@@ -271,6 +337,27 @@ func (p *Profile) Sync(local, remote Syncer) error {
 	return <-p.write(after, before)
 }
 
+// Restore makes to match from regardless of which was modified last, such as to undo a
+// change which was rejected.  Restores are never held back by CheckWrite or CheckDestructive
+func (p *Profile) Restore(from, to Syncer) error {
+	if !from.Exists() {
+		return nil
+	}
+	if from.IsDir() {
+		if to.Exists() {
+			return nil
+		}
+		return <-p.createDir(from, to)
+	}
+	return <-queueItem(p, &changeItem{
+		changeType: changeTypeWrite,
+		from:       from,
+		to:         to,
+		profile:    p,
+		restore:    true,
+	})
+}
+
 func (p *Profile) isConflict(before, after time.Time) bool {
 	if !before.Before(after) {
 		panic("Invalid conflict times")
@@ -339,50 +426,299 @@ func ProfileSyncCount(profileID string) int {
 	return syncing.count(profileID)
 }
 
+// pausedData tracks why each paused profile is paused.  A profile stays paused
+// until every reason it was paused for has been resumed
+type pausedData struct {
+	sync.Mutex
+	cond     *sync.Cond
+	profiles map[string]map[string]struct{}
+}
+
+func (pd *pausedData) pause(profileID, reason string) {
+	pd.Lock()
+	defer pd.Unlock()
+	reasons, ok := pd.profiles[profileID]
+	if !ok {
+		reasons = make(map[string]struct{})
+		pd.profiles[profileID] = reasons
+	}
+	reasons[reason] = struct{}{}
+}
+
+func (pd *pausedData) resume(profileID, reason string) {
+	pd.Lock()
+	defer pd.Unlock()
+	reasons, ok := pd.profiles[profileID]
+	if !ok {
+		return
+	}
+	delete(reasons, reason)
+	if len(reasons) == 0 {
+		delete(pd.profiles, profileID)
+		pd.cond.Broadcast()
+	}
+}
+
+func (pd *pausedData) has(profileID, reason string) bool {
+	pd.Lock()
+	defer pd.Unlock()
+	_, ok := pd.profiles[profileID][reason]
+	return ok
+}
+
+func (pd *pausedData) reasons(profileID string) []string {
+	pd.Lock()
+	defer pd.Unlock()
+	var reasons []string
+	for reason := range pd.profiles[profileID] {
+		if reason != pauseUser {
+			reasons = append(reasons, reason)
+		}
+	}
+	sort.Strings(reasons)
+	return reasons
+}
+
+// wait blocks until the passed in profile is no longer paused, or freehold-sync is shutting down
+func (pd *pausedData) wait(profileID string) {
+	pd.Lock()
+	defer pd.Unlock()
+	for {
+		if _, ok := pd.profiles[profileID]; !ok || isShuttingDown() {
+			return
+		}
+		pd.cond.Wait()
+	}
+}
+
+// release wakes up everything waiting on a paused profile
+func (pd *pausedData) release() {
+	pd.Lock()
+	defer pd.Unlock()
+	pd.cond.Broadcast()
+}
+
+// pauseUser is the reason a profile is paused when paused by the user
+const pauseUser = ""
+
+// Pause suspends running any queued changes for the passed in profile.
+// Changes will continue to be collected, and will run once the profile is resumed
+func Pause(profileID string) {
+	paused.pause(profileID, pauseUser)
+}
+
+// Resume resumes running queued changes for the passed in profile, unless
+// it's still paused for another reason
+func Resume(profileID string) {
+	paused.resume(profileID, pauseUser)
+}
+
+// IsPaused returns whether or not the passed in profile has been paused by the user
+func IsPaused(profileID string) bool {
+	return paused.has(profileID, pauseUser)
+}
+
+// PauseFor suspends running any queued changes for the passed in profile until
+// ResumeFrom is called with the same reason
+func PauseFor(profileID, reason string) {
+	paused.pause(profileID, reason)
+}
+
+// ResumeFrom removes the passed in reason for pausing the profile.  Queued changes
+// will run once the profile isn't paused for any other reasons
+func ResumeFrom(profileID, reason string) {
+	paused.resume(profileID, reason)
+}
+
+// PauseReasons returns the reasons the profile is paused, other than by the user
+func PauseReasons(profileID string) []string {
+	return paused.reasons(profileID)
+}
+
+type runningProfiles struct {
+	sync.RWMutex
+	profiles map[string]*Profile
+}
+
+func (rp *runningProfiles) add(p *Profile) {
+	rp.Lock()
+	defer rp.Unlock()
+	rp.profiles[p.ID()] = p
+}
+
+func (rp *runningProfiles) remove(p *Profile) {
+	rp.Lock()
+	defer rp.Unlock()
+	delete(rp.profiles, p.ID())
+}
+
+func (rp *runningProfiles) get(profileID string) *Profile {
+	rp.RLock()
+	defer rp.RUnlock()
+	return rp.profiles[profileID]
+}
+
+// Running returns the started profile for the passed in ID
+// or nil if the profile isn't currently running
+func Running(profileID string) *Profile {
+	return running.get(profileID)
+}
+
+func (rp *runningProfiles) all() []*Profile {
+	rp.RLock()
+	defer rp.RUnlock()
+	all := make([]*Profile, 0, len(rp.profiles))
+	for _, p := range rp.profiles {
+		all = append(all, p)
+	}
+	return all
+}
+
+// RunningProfiles returns all of the started profiles
+func RunningProfiles() []*Profile {
+	return running.all()
+}
+
 type changeItem struct {
 	changeType int
 	from, to   Syncer
 	profile    *Profile
 	done       chan error
+	restore    bool // restores aren't held back by CheckWrite or CheckDestructive
 }
 
 func (c *changeItem) runChange() {
-	switch c.changeType {
-	case changeTypeCreateDir:
-		dir, err := c.to.CreateDir()
+	if c.profile.CheckRoots != nil {
+		err := c.profile.CheckRoots(c.changeType == changeTypeDelete)
 		if err != nil {
 			c.done <- err
 			return
 		}
-		err = dir.StartMonitor(c.profile)
+	}
+	if !c.restore && c.profile.CheckWrite != nil && c.changeType == changeTypeWrite {
+		err := c.profile.CheckWrite(c.from, c.to)
+		if err != nil {
+			c.done <- err
+			return
+		}
+	}
+	if !c.restore && c.profile.CheckDestructive != nil && c.destructive() {
+		err := c.profile.CheckDestructive(changeNames[c.changeType], c.to)
 		if err != nil {
 			c.done <- err
 			return
 		}
-		c.done <- c.from.StartMonitor(c.profile)
+	}
 
+	start := time.Now()
+	record := &activity.Activity{
+		When:        start,
+		Profile:     c.profile.ID(),
+		Direction:   c.direction(),
+		Change:      changeNames[c.changeType],
+		Path:        filepath.ToSlash(c.to.Path(c.profile)),
+		OldModified: c.to.Modified(),
+	}
+	switch c.changeType {
+	case changeTypeWrite:
+		record.Size = c.from.Size()
+		record.NewModified = c.from.Modified()
 	case changeTypeDelete:
-		c.done <- c.to.Delete()
+		record.Size = c.to.Size()
+	}
+
+	err := c.run()
+	record.Duration = time.Since(start)
+	if err != nil {
+		record.Outcome = activity.OutcomeFailed
+		record.Error = err.Error()
+		activity.Record(record)
+		c.done <- &ChangeError{
+			Change: record.Change,
+			Err:    err,
+		}
+		return
+	}
+
+	record.Outcome = activity.OutcomeSuccess
+	activity.Record(record)
+	log.Debug(fmt.Sprintf("Completed %s of %s", record.Change, c.to.ID()), LogType, log.Fields{
+		Profile:  record.Profile,
+		Path:     record.Path,
+		Change:   record.Change,
+		Bytes:    record.Size,
+		Duration: record.Duration,
+	})
+	c.done <- nil
+}
+
+// destructive returns whether the change deletes or overwrites a file
+func (c *changeItem) destructive() bool {
+	return c.changeType == changeTypeDelete || (c.changeType == changeTypeWrite && c.to.Exists())
+}
+
+// direction returns whether the change is being made to the remote or the local file
+func (c *changeItem) direction() string {
+	if strings.HasPrefix(c.to.ID(), c.profile.Remote.ID()) {
+		return activity.DirectionUp
+	}
+	return activity.DirectionDown
+}
+
+func (c *changeItem) run() error {
+	switch c.changeType {
+	case changeTypeCreateDir:
+		dir, err := c.to.CreateDir()
+		if err != nil {
+			return err
+		}
+		err = dir.StartMonitor(c.profile)
+		if err != nil {
+			return err
+		}
+		return c.from.StartMonitor(c.profile)
+
+	case changeTypeDelete:
+		return c.to.Delete()
 	case changeTypeRename:
-		c.done <- c.to.Rename()
+		return c.to.Rename()
 	case changeTypeWrite:
 		r, err := c.from.Open()
 		if err != nil {
-			c.done <- err
-			return
+			return err
 		}
-		c.done <- c.to.Write(r, c.from.Size(), c.from.Modified())
+		return c.to.Write(r, c.from.Size(), c.from.Modified())
 	}
+	return nil
 }
 
 func queueChange(p *Profile, from, to Syncer, changeType int) chan error {
-	done := make(chan error)
-	p.changes <- &changeItem{
+	return queueItem(p, &changeItem{
 		changeType: changeType,
 		from:       from,
 		to:         to,
 		profile:    p,
-		done:       done,
+	})
+}
+
+func queueItem(p *Profile, c *changeItem) chan error {
+	done := make(chan error, 1)
+	c.done = done
+	if p.changes == nil || !p.changes.push(c) {
+		if isShuttingDown() {
+			done <- ErrShuttingDown
+		} else {
+			done <- ErrProfileStopped
+		}
 	}
 	return done
 }
+
+// QueueLength returns the number of changes waiting to run on the passed in profile
+func QueueLength(profileID string) int {
+	p := running.get(profileID)
+	if p == nil || p.changes == nil {
+		return 0
+	}
+	return p.changes.len()
+}
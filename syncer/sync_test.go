@@ -0,0 +1,67 @@
+// Copyright 2015 Tim Shannon. All rights reserved.
+// Use of this source code is governed by the MIT license
+// that can be found in the LICENSE file.
+
+package syncer
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPauseReasons(t *testing.T) {
+	type op struct {
+		pause  bool
+		reason string
+	}
+	tests := []struct {
+		name    string
+		ops     []op
+		paused  bool
+		user    bool
+		reasons []string
+	}{
+		{"none", nil, false, false, nil},
+		{"user", []op{{true, pauseUser}}, true, true, nil},
+		{"user resumed", []op{{true, pauseUser}, {false, pauseUser}}, false, false, nil},
+		{"reason", []op{{true, "offline"}}, true, false, []string{"offline"}},
+		{"reasons sorted", []op{{true, "offline"}, {true, "disk full"}, {true, pauseUser}}, true, true,
+			[]string{"disk full", "offline"}},
+		{"other reason remains", []op{{true, "offline"}, {true, pauseUser}, {false, pauseUser}}, true, false,
+			[]string{"offline"}},
+		{"all resumed", []op{{true, "offline"}, {true, "disk full"}, {false, "disk full"}, {false, "offline"}},
+			false, false, nil},
+		{"resume unknown reason", []op{{true, "offline"}, {false, "disk full"}}, true, false, []string{"offline"}},
+	}
+
+	for _, test := range tests {
+		pd := &pausedData{profiles: make(map[string]map[string]struct{})}
+		pd.cond = sync.NewCond(&pd.Mutex)
+		for _, o := range test.ops {
+			if o.pause {
+				pd.pause("profile", o.reason)
+			} else {
+				pd.resume("profile", o.reason)
+			}
+		}
+
+		_, paused := pd.profiles["profile"]
+		if paused != test.paused {
+			t.Errorf("%s: paused is %t, expected %t", test.name, paused, test.paused)
+		}
+		if user := pd.has("profile", pauseUser); user != test.user {
+			t.Errorf("%s: paused by user is %t, expected %t", test.name, user, test.user)
+		}
+		reasons := pd.reasons("profile")
+		if len(reasons) != len(test.reasons) {
+			t.Errorf("%s: reasons are %v, expected %v", test.name, reasons, test.reasons)
+			continue
+		}
+		for i := range reasons {
+			if reasons[i] != test.reasons[i] {
+				t.Errorf("%s: reasons are %v, expected %v", test.name, reasons, test.reasons)
+				break
+			}
+		}
+	}
+}
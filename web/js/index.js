@@ -115,6 +115,19 @@ $(document).ready(function() {
                     r.set("currentProfile.profileError", result.responseJSON.message);
                 });
         },
+        "remarkProfile": function(event) {
+            r.set("loading", true);
+            event.context.remark()
+                .done(function() {
+                    r.set("page", "main");
+                    r.set("loading", false);
+                    loadProfiles();
+                })
+                .fail(function(result) {
+                    r.set("loading", false);
+                    r.set("currentProfile.profileError", result.responseJSON.message);
+                });
+        },
         "deleteProfile": function(event) {
             event.context.delete()
                 .done(function() {
@@ -247,6 +260,11 @@ $(document).ready(function() {
             this.localPath = "";
             this.remotePath = "";
             this.client = new Client();
+            this.localMonitor = 0;
+            this.remotePollingSeconds = 0;
+            this.massChangeFiles = 0;
+            this.massChangePercent = 0;
+            this.massChangeWindowSeconds = 0;
         } else {
             this.id = profile.id;
             this.name = profile.name;
@@ -258,12 +276,22 @@ $(document).ready(function() {
             this.localPath = profile.localPath;
             this.remotePath = profile.remotePath;
             this.client = new Client(profile.client);
+            this.localMonitor = profile.localMonitor;
+            this.remotePollingSeconds = profile.remotePollingSeconds;
+            this.massChangeFiles = profile.massChangeFiles;
+            this.massChangePercent = profile.massChangePercent;
+            this.massChangeWindowSeconds = profile.massChangeWindowSeconds;
 
         }
         //methods
         this.saveNew = function() {
             this.conflictDurationSeconds = Number(this.conflictDurationSeconds);
             this.conflictResolution = Number(this.conflictResolution);
+            this.localMonitor = Number(this.localMonitor);
+            this.remotePollingSeconds = Number(this.remotePollingSeconds);
+            this.massChangeFiles = Number(this.massChangeFiles);
+            this.massChangePercent = Number(this.massChangePercent);
+            this.massChangeWindowSeconds = Number(this.massChangeWindowSeconds);
             return $.ajax({
                 type: "POST",
                 url: "/profile/",
@@ -274,6 +302,11 @@ $(document).ready(function() {
         this.save = function() {
             this.conflictDurationSeconds = Number(this.conflictDurationSeconds);
             this.conflictResolution = Number(this.conflictResolution);
+            this.localMonitor = Number(this.localMonitor);
+            this.remotePollingSeconds = Number(this.remotePollingSeconds);
+            this.massChangeFiles = Number(this.massChangeFiles);
+            this.massChangePercent = Number(this.massChangePercent);
+            this.massChangeWindowSeconds = Number(this.massChangeWindowSeconds);
             return $.ajax({
                 type: "PUT",
                 url: "/profile/",
@@ -281,6 +314,16 @@ $(document).ready(function() {
                 data: JSON.stringify(this),
             });
         };
+        this.remark = function() {
+            return $.ajax({
+                type: "POST",
+                url: "/profile/remark/",
+                dataType: "json",
+                data: JSON.stringify({
+                    "id": this.id
+                }),
+            });
+        };
         this.delete = function() {
             this.conflictDurationSeconds = Number(this.conflictDurationSeconds);
             this.conflictResolution = Number(this.conflictResolution);